@@ -50,7 +50,7 @@ func (e Entry) Indexes() dialect.Indexes {
 type PlayerComment struct {
 	Id        int32          `ddl:"auto,size=100" json:"id"`
 	PlayerID  int32          `json:"player_id"`
-	EntryID   int32          `json:"entry_id"`
+	EntryID   int32          `json:"entry_id" ddl:"fk=entry.id"`
 	Comment   sql.NullString `json:"comment" ddl:"null,size=99"`
 	CreatedAt time.Time      `json:"created_at"`
 	updatedAt time.Time
@@ -66,18 +66,9 @@ func (pc PlayerComment) Indexes() dialect.Indexes {
 	}
 }
 
-func (pc PlayerComment) ForeignKeys() dialect.ForeignKeys {
-	return dialect.ForeignKeys{
-		mysql.AddForeignKey(
-			[]string{"player_id"},
-			[]string{"id"},
-			"player",
-		),
-		mysql.AddForeignKey(
-			[]string{"entry_id"},
-			[]string{"id"},
-			"entry",
-		),
+func (pc PlayerComment) References() map[string]interface{} {
+	return map[string]interface{}{
+		"PlayerID": &User{},
 	}
 }
 
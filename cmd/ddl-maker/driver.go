@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	ddlmaker "github.com/mnhkahn/ddl-maker"
+)
+
+const (
+	// markerComment selects structs that have directive comment in their doc comments
+	markerComment = "comment"
+	// markerMethod selects structs that implement Table() method
+	markerMethod = "method"
+	// directive is the comment that marks the struct as a table
+	directive = "//ddl:table"
+)
+
+var (
+	// errNoStructs means no struct is marked in the package
+	errNoStructs = errors.New("no struct is marked as table")
+	// errInvalidMarker means the marker is neither comment nor method
+	errInvalidMarker = errors.New("invalid marker")
+	// errMainPackage means the package can not be imported by the driver program
+	errMainPackage = errors.New("package main can not be imported")
+)
+
+// goPackage is the package resolved by go list.
+// GoFiles and CgoFiles are the source files selected by build constraints, relative to Dir.
+type goPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	CgoFiles   []string
+	Module     *struct {
+		Dir string
+	}
+}
+
+// loadPackage resolves the package pattern (e.g. ., ./model, example.com/app/model) by go list.
+func loadPackage(pattern string) (goPackage, error) {
+	var pkg goPackage
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "list", "-json", pattern)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return pkg, fmt.Errorf("error go list: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if err := json.Unmarshal(out, &pkg); err != nil {
+		return pkg, fmt.Errorf("error decode go list: %w", err)
+	}
+	if pkg.Name == "main" {
+		return pkg, fmt.Errorf("%w: %s", errMainPackage, pkg.ImportPath)
+	}
+	if pkg.Module == nil {
+		return pkg, fmt.Errorf("%s is not in a module", pkg.ImportPath)
+	}
+	return pkg, nil
+}
+
+// scanStructs parses Go source files of the package selected by build constraints, and returns
+// names of exported structs selected by the marker in sorted order. Test files are not scanned.
+func scanStructs(pkg goPackage, marker string) ([]string, error) {
+	if marker != markerComment && marker != markerMethod {
+		return nil, fmt.Errorf("%w: %s", errInvalidMarker, marker)
+	}
+
+	structs := make(map[string]bool)
+	marked := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, file := range append(append([]string{}, pkg.GoFiles...), pkg.CgoFiles...) {
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, file), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+				for _, s := range decl.Specs {
+					ts := s.(*ast.TypeSpec)
+					if _, ok := ts.Type.(*ast.StructType); !ok || !ts.Name.IsExported() {
+						continue
+					}
+					structs[ts.Name.Name] = true
+					doc := ts.Doc
+					if doc == nil && len(decl.Specs) == 1 {
+						doc = decl.Doc
+					}
+					if marker == markerComment && hasDirective(doc) {
+						marked[ts.Name.Name] = true
+					}
+				}
+			case *ast.FuncDecl:
+				if marker == markerMethod && isTableMethod(decl) {
+					marked[receiverName(decl.Recv.List[0].Type)] = true
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name := range marked {
+		if structs[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// hasDirective reports whether the comment has //ddl:table line.
+func hasDirective(cg *ast.CommentGroup) bool {
+	if cg == nil {
+		return false
+	}
+	for _, c := range cg.List {
+		if strings.TrimSpace(c.Text) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// isTableMethod reports whether the function is Table() string method.
+func isTableMethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Name.Name != "Table" {
+		return false
+	}
+	if len(fn.Type.Params.List) != 0 || fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+		return false
+	}
+	result, ok := fn.Type.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "string" && len(fn.Type.Results.List[0].Names) <= 1
+}
+
+// receiverName returns the type name of the receiver (e.g. T of *T).
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+var driverTemplate = template.Must(template.New("driver").Parse(`// Code generated by ddl-maker. DO NOT EDIT.
+
+package main
+
+import (
+	"log"
+
+	ddlmaker "github.com/mnhkahn/ddl-maker"
+	target "{{ .Package.ImportPath }}"
+)
+
+func main() {
+	conf, err := ddlmaker.LoadConfig({{ printf "%q" .ConfigPath }})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dm, err := ddlmaker.New(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := dm.AddStruct(
+{{- range .Structs }}
+		&target.{{ . }}{},
+{{- end }}
+	); err != nil {
+		log.Fatal(err)
+	}
+	if err := dm.Generate(); err != nil {
+		log.Fatal(err)
+	}
+}
+`))
+
+// runDriver writes the driver program and the config into a temporary directory of
+// the module, and runs it by go run. The directory begins with "_" so that it is
+// ignored by ./... patterns.
+func runDriver(pkg goPackage, structs []string, conf ddlmaker.Config) error {
+	dir, err := os.MkdirTemp(pkg.Module.Dir, "_ddl-maker-")
+	if err != nil {
+		return fmt.Errorf("error create driver directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "config.json")
+	data, err := json.Marshal(conf)
+	if err != nil {
+		return fmt.Errorf("error encode config: %w", err)
+	}
+	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+		return fmt.Errorf("error write config: %w", err)
+	}
+
+	var src bytes.Buffer
+	err = driverTemplate.Execute(&src, struct {
+		Package    goPackage
+		Structs    []string
+		ConfigPath string
+	}{pkg, structs, configPath})
+	if err != nil {
+		return fmt.Errorf("error write driver: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), src.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("error write driver: %w", err)
+	}
+
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir))
+	cmd.Dir = pkg.Module.Dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error run driver: %w", err)
+	}
+	return nil
+}
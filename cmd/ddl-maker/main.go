@@ -0,0 +1,141 @@
+// Command ddl-maker generates DDL from structs of a Go package without a hand-written main.
+//
+// Structs are discovered by the marker. "comment" marker selects structs that have
+// //ddl:table in their doc comments, and "method" marker selects structs that
+// implement Table() method. ddl-maker writes a temporary program that adds the
+// structs to DDLMaker, and runs it by go run.
+//
+//	ddl-maker -d mysql -o ./sql/schema.sql ./model
+//
+// Settings can be checked in as a config file (see ddlmaker.LoadConfig), and flags override it.
+//
+//	ddl-maker -config ddl-maker.yaml ./model
+//
+// It can be used from go:generate.
+//
+//	//go:generate go run github.com/mnhkahn/ddl-maker/cmd/ddl-maker -d mysql -o schema.sql .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	ddlmaker "github.com/mnhkahn/ddl-maker"
+)
+
+func main() {
+	var (
+		driver      string
+		engine      string
+		charset     string
+		outFilePath string
+		configPath  string
+		marker      string
+		layout      string
+		docComments bool
+	)
+	flag.StringVar(&driver, "d", "", "set driver")
+	flag.StringVar(&driver, "driver", "", "set driver")
+	flag.StringVar(&outFilePath, "o", defaultOutFilePath, "set ddl output file path")
+	flag.StringVar(&outFilePath, "outfile", defaultOutFilePath, "set ddl output file path")
+	flag.StringVar(&engine, "e", defaultEngine, "set driver engine")
+	flag.StringVar(&engine, "engine", defaultEngine, "set driver engine")
+	flag.StringVar(&charset, "c", defaultCharset, "set driver charset")
+	flag.StringVar(&charset, "charset", defaultCharset, "set driver charset")
+	flag.StringVar(&configPath, "config", "", "set config file (.json, .yaml, .yml or .toml). Flags override it")
+	flag.StringVar(&marker, "m", markerComment, "set marker of structs (comment or method)")
+	flag.StringVar(&marker, "marker", markerComment, "set marker of structs (comment or method)")
+	flag.StringVar(&layout, "l", "", "set output layout (single, table or statement)")
+	flag.StringVar(&layout, "layout", "", "set output layout (single, table or statement)")
+	flag.BoolVar(&docComments, "doc", false, "use doc comments as column and table comments")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: ddl-maker [flags] [package]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var conf ddlmaker.Config
+	if configPath != "" {
+		var err error
+		if conf, err = ddlmaker.LoadConfig(configPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "d", "driver":
+			conf.DB.Driver = driver
+		case "o", "outfile":
+			conf.OutFilePath = outFilePath
+		case "e", "engine":
+			conf.DB.Engine = engine
+		case "c", "charset":
+			conf.DB.Charset = charset
+		case "l", "layout":
+			conf.Layout = layout
+		case "doc":
+			conf.DocComments = docComments
+		}
+	})
+	conf = withDefaults(conf)
+
+	if conf.DB.Driver == "" {
+		log.Fatal("Please set driver name. -d or -driver")
+	}
+	pattern := "."
+	if flag.NArg() > 0 {
+		pattern = flag.Arg(0)
+	}
+
+	out, err := filepath.Abs(conf.OutFilePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf.OutFilePath = out
+	if err := run(pattern, conf, marker); err != nil {
+		log.Fatal(err)
+	}
+}
+
+const (
+	defaultOutFilePath = "./sql/master.sql"
+	defaultEngine      = "InnoDB"
+	defaultCharset     = "utf8mb4"
+)
+
+// withDefaults fills the output path, engine and charset that are set by neither flags nor the config file.
+func withDefaults(conf ddlmaker.Config) ddlmaker.Config {
+	if conf.OutFilePath == "" {
+		conf.OutFilePath = defaultOutFilePath
+	}
+	if conf.DB.Engine == "" {
+		conf.DB.Engine = defaultEngine
+	}
+	if conf.DB.Charset == "" {
+		conf.DB.Charset = defaultCharset
+	}
+	return conf
+}
+
+// run generates DDL of structs in the package.
+func run(pattern string, conf ddlmaker.Config, marker string) error {
+	pkg, err := loadPackage(pattern)
+	if err != nil {
+		return fmt.Errorf("error load package %s: %w", pattern, err)
+	}
+	structs, err := scanStructs(pkg, marker)
+	if err != nil {
+		return fmt.Errorf("error scan structs of %s: %w", pkg.ImportPath, err)
+	}
+	if len(structs) == 0 {
+		return fmt.Errorf("%w: %s", errNoStructs, pkg.ImportPath)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.OutFilePath), 0o755); err != nil {
+		return fmt.Errorf("error create output directory: %w", err)
+	}
+	return runDriver(pkg, structs, conf)
+}
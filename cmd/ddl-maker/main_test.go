@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	ddlmaker "github.com/mnhkahn/ddl-maker"
+)
+
+func TestScanStructs(t *testing.T) {
+	tests := []struct {
+		name    string
+		marker  string
+		want    []string
+		wantErr error
+	}{
+		{name: "[Normal] directive comment", marker: markerComment, want: []string{"Entry", "Player"}},
+		{name: "[Normal] Table() method", marker: markerMethod, want: []string{"Bookmark", "Entry"}},
+		{name: "[Error] unknown marker", marker: "tag", wantErr: errInvalidMarker},
+	}
+	pkg, err := loadPackage("./testdata/model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scanStructs(pkg, tt.marker)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch error want=%v, got=%v", tt.wantErr, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go run is skipped in short mode")
+	}
+
+	t.Run("[Normal] generate DDL of marked structs", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "schema.sql")
+		conf := ddlmaker.Config{
+			DB:          ddlmaker.DBConfig{Driver: "mysql", Engine: "InnoDB", Charset: "utf8mb4"},
+			OutFilePath: out,
+			Tables:      ddlmaker.TableFilter{Exclude: []string{"player"}},
+		}
+		if err := run("./testdata/model", conf, markerComment); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(got), "CREATE TABLE `entry`") {
+			t.Errorf("entry is not generated:\n%s", got)
+		}
+		for _, table := range []string{"CREATE TABLE `player`", "CREATE TABLE `bookmark`"} {
+			if strings.Contains(string(got), table) {
+				t.Errorf("%s is generated:\n%s", table, got)
+			}
+		}
+	})
+
+	t.Run("[Error] package main", func(t *testing.T) {
+		err := run(".", ddlmaker.Config{DB: ddlmaker.DBConfig{Driver: "mysql"}}, markerComment)
+		if !errors.Is(err, errMainPackage) {
+			t.Errorf("mismatch want=%v, got=%v", errMainPackage, err)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+//go:build ignore
+
+package model
+
+// Legacy is excluded by the build constraint, so it is not scanned.
+//
+//ddl:table
+type Legacy struct {
+	ID uint64 `ddl:"auto,pk"`
+}
+
+func (Legacy) Table() string {
+	return "legacy"
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+//ddl:table
+type Player struct {
+	ID        uint64 `ddl:"auto,pk"`
+	Name      string
+	CreatedAt time.Time
+}
+
+// Entry is marked by the directive.
+//
+//ddl:table
+type Entry struct {
+	ID       uint64 `ddl:"auto,pk"`
+	PlayerID uint64 `ddl:"fk=player.id"`
+	Title    string `ddl:"size=100"`
+}
+
+func (e *Entry) Table() string {
+	return "entry"
+}
+
+type Bookmark struct {
+	ID      uint64 `ddl:"auto,pk"`
+	EntryID uint64
+}
+
+func (b Bookmark) Table() string {
+	return "bookmark"
+}
+
+// Summary is not a table.
+type Summary struct {
+	Count int64
+}
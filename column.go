@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
 )
 
 // column is the model for mapping structure field to table column.
@@ -14,6 +15,22 @@ type column struct {
 	name string
 	// typeName is name of type that defined in golang
 	typeName string
+	// goType is fully qualified name of named type (e.g. github.com/google/uuid.UUID)
+	goType string
+	// kind is underlying type of named type used when the dialect does not know typeName
+	kind string
+	// types is the type registry of DDLMaker
+	types dialect.Types
+	// typer declares SQL type if the Go type implements DDLTyper
+	typer DDLTyper
+	// valueType is the Go type returned by Value() if the Go type implements driver.Valuer
+	valueType string
+	// enumValues are returned by EnumValues() if the Go type implements Enumer
+	enumValues []string
+	// nullable is true if the Go type can be NULL (e.g. *T, sql.NullString)
+	nullable bool
+	// doc is the doc comment of the structure field
+	doc string
 	// tag is that specified in the structure field
 	tag string
 	// dialect is interface that eliminates differences in DB drivers.
@@ -40,12 +57,109 @@ func (c column) size() (uint64, error) {
 	return strconv.ParseUint(specs["size"], 10, 64)
 }
 
+// typeSpec returns type name specified by "type" tag and options of the type
+// specified by "size", "precision" and "scale" tags. Precision and scale can be
+// specified by the type tag as well (e.g. type=decimal(10,2)).
+// "enum" and "set" tags declare the type with values separated by "|".
+func (c column) typeSpec() (string, spec.Type, error) {
+	t := spec.Type{Column: c.name}
+	specs := c.specs()
+	_, t.AutoIncrement = specs["auto"]
+
+	for _, kind := range []string{"enum", "set"} {
+		values, ok := specs[kind]
+		if !ok {
+			continue
+		}
+		if _, ok := specs["type"]; ok || values == "" || t.Values != nil {
+			return "", t, fmt.Errorf("%w: %s tag of %s", ErrInvalidTag, kind, c.name)
+		}
+		specs["type"] = kind
+		t.Values = strings.Split(values, "|")
+	}
+
+	size, err := c.size()
+	if err != nil {
+		return "", t, fmt.Errorf("error size parse error: %w", err)
+	}
+	t.Size = size
+
+	for key, dst := range map[string]*uint64{"precision": &t.Precision, "scale": &t.Scale} {
+		if specs[key] == "" {
+			continue
+		}
+		if *dst, err = strconv.ParseUint(specs[key], 10, 64); err != nil {
+			return "", t, fmt.Errorf("error %s parse error: %w", key, err)
+		}
+	}
+
+	typeName := specs["type"]
+	if open := strings.Index(typeName, "("); open > 0 && strings.HasSuffix(typeName, ")") &&
+		isDecimal(typeName[:open]) {
+		args := strings.Split(typeName[open+1:len(typeName)-1], ",")
+		if len(args) > 2 {
+			return "", t, fmt.Errorf("error type parse error: %s", typeName)
+		}
+		for i, arg := range args {
+			v, err := strconv.ParseUint(strings.TrimSpace(arg), 10, 64)
+			if err != nil {
+				return "", t, fmt.Errorf("error type parse error: %s: %w", typeName, err)
+			}
+			if i == 0 {
+				t.Precision = v
+			} else {
+				t.Scale = v
+			}
+		}
+		typeName = strings.ToLower(typeName[:open])
+	}
+	return typeName, t, nil
+}
+
+func isDecimal(typeName string) bool {
+	return strings.EqualFold(typeName, "decimal") || strings.EqualFold(typeName, "numeric")
+}
+
+// splitTag splits the tag by commas that are not enclosed in parentheses.
+func splitTag(tag string) []string {
+	var elems []string
+	depth, start := 0, 0
+	for i, r := range tag {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				elems = append(elems, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(elems, tag[start:])
+}
+
+// normalizeTag trims spaces around keys and values of the tag. Spaces in
+// values are kept (e.g. check=price >= 0).
+func normalizeTag(tag string) string {
+	elems := splitTag(tag)
+	for i, elem := range elems {
+		key, value, ok := strings.Cut(elem, "=")
+		elems[i] = strings.TrimSpace(key)
+		if ok {
+			elems[i] += "=" + strings.TrimSpace(value)
+		}
+	}
+	return strings.Join(elems, ",")
+}
+
 // specs converts each tag of a golang structure into a key-value format map
 func (c column) specs() map[string]string {
-	elems := strings.Split(c.tag, ",")
+	elems := splitTag(c.tag)
 	specs := make(map[string]string, len(elems))
 	for _, elem := range elems {
-		ss := strings.Split(elem, "=")
+		ss := strings.SplitN(elem, "=", 2)
 		switch len(ss) {
 		case 1:
 			specs[ss[0]] = ""
@@ -57,38 +171,100 @@ func (c column) specs() map[string]string {
 	return specs
 }
 
-// attribute returns DB attributes (constraints)
-func (c column) attribute() string {
-	var attributes []string
+// attributes returns attributes of the column specified by tags and the Go type.
+// The column is nullable if "null" tag is specified, or the Go type can be NULL
+// (e.g. *T, sql.NullString) and "notnull" tag is not specified.
+func (c column) attributes() (spec.Attributes, error) {
 	specs := c.specs()
-
-	if _, ok := specs["null"]; ok {
-		attributes = append(attributes, "NULL")
-	} else {
-		attributes = append(attributes, "NOT NULL") // 默认都加了NOT NULL
+	_, null := specs["null"]
+	_, notNull := specs["notnull"]
+	if null && notNull {
+		return spec.Attributes{}, fmt.Errorf("%w: %s is both null and notnull", ErrInvalidTag, c.name)
 	}
 
-	if defaultVal, ok := specs["default"]; ok {
-		attributes = append(attributes, "DEFAULT")
-		attributes = append(attributes, defaultVal)
+	_, auto := specs["auto"]
+	return spec.Attributes{
+		Null:          null || (c.nullable && !notNull),
+		Default:       specs["default"],
+		OnUpdate:      specs["update"],
+		AutoIncrement: auto,
+		Comment:       c.Comment(),
+	}, nil
+}
+
+// Spec returns the definition of the column parsed from tags.
+// UNIQUE and PRIMARY KEY are declared by the table, so they are not reported here.
+func (c column) Spec() (spec.Column, error) {
+	a, err := c.attributes()
+	if err != nil {
+		return spec.Column{}, err
 	}
-	if defaultVal, ok := specs["update"]; ok {
-		attributes = append(attributes, "ON UPDATE")
-		attributes = append(attributes, defaultVal)
+	specs := c.specs()
+	_, stored := specs["stored"]
+	return spec.Column{Attributes: a, Generated: specs["generated"], Stored: stored}, nil
+}
+
+// attribute returns DB attributes (constraints) rendered by the dialect
+func (c column) attribute() (string, error) {
+	a, err := c.attributes()
+	if err != nil {
+		return "", err
 	}
+	return c.dialect.AttributesSQL(a)
+}
 
-	if _, ok := specs["auto"]; ok {
-		attributes = append(attributes, c.dialect.AutoIncrement())
+// collation returns charset and collation attributes specified by "charset" and "collate" tags.
+func (c column) collation() (string, error) {
+	specs := c.specs()
+	charset, collation := specs["charset"], specs["collate"]
+	if charset == "" && collation == "" {
+		return "", nil
+	}
+	collator, ok := c.dialect.(dialect.Collator)
+	if !ok {
+		return "", fmt.Errorf("%w: charset and collate tags are not supported by %T", ErrInvalidTag, c.dialect)
 	}
+	return collator.CollationSQL(charset, collation)
+}
 
-	if defaultVal, ok := specs["comment"]; ok {
-		attributes = append(attributes, "COMMENT")
-		attributes = append(attributes, fmt.Sprintf(`'%s'`, defaultVal))
-	} else {
-		attributes = append(attributes, fmt.Sprintf(`COMMENT '%s'`, c.Name()))
+// generated returns the definition of the column of columnType generated by the expression of
+// "generated" tag. It is rendered by the dialect, and the column is VIRTUAL unless "stored" tag is specified.
+func (c column) generated(columnType string) (string, error) {
+	generator, ok := c.dialect.(dialect.Generator)
+	if !ok {
+		return "", fmt.Errorf("%w: generated tag is not supported by %T", ErrInvalidTag, c.dialect)
 	}
+	a, err := c.attributes()
+	if err != nil {
+		return "", err
+	}
+	specs := c.specs()
+	_, stored := specs["stored"]
+	return generator.GeneratedSQL(columnType, specs["generated"], stored, a)
+}
 
-	return strings.Join(attributes, " ")
+// validateGenerated returns error if tags that conflict with "generated" tag are specified.
+func (c column) validateGenerated() error {
+	specs := c.specs()
+	_, generated := specs["generated"]
+	_, stored := specs["stored"]
+	_, virtual := specs["virtual"]
+	switch {
+	case generated && specs["generated"] == "":
+		return fmt.Errorf("%w: generated tag of %s without expression", ErrInvalidTag, c.name)
+	case !generated && (stored || virtual):
+		return fmt.Errorf("%w: stored or virtual tag of %s without generated tag", ErrInvalidTag, c.name)
+	case stored && virtual:
+		return fmt.Errorf("%w: %s is both stored and virtual", ErrInvalidTag, c.name)
+	}
+	if generated {
+		for _, key := range []string{"default", "update", "auto"} {
+			if _, ok := specs[key]; ok {
+				return fmt.Errorf("%w: generated column %s can not have %s tag", ErrInvalidTag, c.name, key)
+			}
+		}
+	}
+	return nil
 }
 
 // Name return column name. This name is snake case.
@@ -96,28 +272,98 @@ func (c column) Name() string {
 	return c.name
 }
 
-// ToSQL convert struct field to sql.
-func (c column) ToSQL() (string, error) {
-	var columnType string
-	specs := c.specs()
-
-	if typeName, ok := specs["type"]; ok {
-		columnType = typeName
-	} else {
-		columnType = c.typeName
+// Comment return column comment specified by "comment" tag.
+// If the tag is not specified, it returns the doc comment of the field or column name.
+func (c column) Comment() string {
+	if comment, ok := c.specs()["comment"]; ok {
+		return comment
 	}
+	if c.doc != "" {
+		return c.doc
+	}
+	return c.name
+}
 
+// ToSQL convert struct field to sql.
+func (c column) ToSQL() (string, error) {
 	name := c.dialect.Quote(c.name)
-	size, err := c.size()
+	columnType, t, err := c.typeSpec()
 	if err != nil {
-		return "", fmt.Errorf("error size parse error: %w", err)
+		return "", err
+	}
+	if err := c.validateGenerated(); err != nil {
+		return "", err
 	}
 
-	sql, err := c.dialect.ToSQL(columnType, size)
+	sql, err := c.sqlType(columnType, t)
 	if err != nil {
 		return "", fmt.Errorf("can not convert struct field to sql: %s, error is: %w", c.name, err)
 	}
-	attribute := c.attribute()
+	collation, err := c.collation()
+	if err != nil {
+		return "", fmt.Errorf("error collation of %s: %w", c.name, err)
+	}
+	if collation != "" {
+		sql += " " + collation
+	}
+	if _, ok := c.specs()["generated"]; ok {
+		definition, err := c.generated(sql)
+		if err != nil {
+			return "", fmt.Errorf("error generated column %s: %w", c.name, err)
+		}
+		return fmt.Sprintf("%s %s", name, definition), nil
+	}
+	attribute, err := c.attribute()
+	if err != nil {
+		return "", fmt.Errorf("error attribute of %s: %w", c.name, err)
+	}
+	if attribute == "" {
+		return fmt.Sprintf("%s %s", name, sql), nil
+	}
 
 	return fmt.Sprintf("%s %s %s", name, sql, attribute), nil
 }
+
+// sqlType resolves SQL type of the column. The type tag is used first, then
+// types registered to DDLMaker and the dialect, DDLType() and EnumValues() of the Go type,
+// the Go type and its underlying kind, and the type returned by Value() at last.
+// Precision and scale without the type tag means decimal.
+func (c column) sqlType(typeTag string, t spec.Type) (string, error) {
+	if typeTag != "" {
+		return c.dialect.ToSQL(typeTag, t)
+	}
+	if c.goType != "" {
+		if fn, ok := c.types.Lookup(c.goType); ok {
+			return fn(t), nil
+		}
+		if fn, ok := dialect.LookupType(c.dialect, c.goType); ok {
+			return fn(t), nil
+		}
+	}
+	if c.typer != nil {
+		if sql := c.typer.DDLType(dialect.Driver(c.dialect)); sql != "" {
+			return sql, nil
+		}
+	}
+	if len(c.enumValues) > 0 {
+		t.Values = c.enumValues
+		return c.dialect.ToSQL("enum", t)
+	}
+	if t.Precision > 0 || t.Scale > 0 {
+		return c.dialect.ToSQL("decimal", t)
+	}
+
+	sql, err := c.dialect.ToSQL(c.typeName, t)
+	if err == nil {
+		return sql, nil
+	}
+	for _, typeName := range []string{c.kind, c.valueType} {
+		if typeName == "" {
+			continue
+		}
+		if sql, fallbackErr := c.dialect.ToSQL(typeName, t); fallbackErr == nil {
+			return sql, nil
+		}
+	}
+	return "", err
+}
@@ -3,10 +3,15 @@ package ddlmaker
 import (
 	"errors"
 	"reflect"
+	"strconv"
 	"testing"
 
-	"github.com/nao1215/ddl-maker/dialect"
-	"github.com/nao1215/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mock"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlserver"
 )
 
 func TestSize(t *testing.T) {
@@ -48,23 +53,96 @@ func TestSpecs(t *testing.T) {
 func TestAttribute(t *testing.T) {
 	c := column{dialect: mysql.MySQL{}}
 
-	if c.attribute() != "NOT NULL" {
-		t.Fatalf("error column attribute. result:%s", c.attribute())
+	if got, err := c.attribute(); err != nil || got != "NOT NULL COMMENT ''" {
+		t.Fatalf("error column attribute. result:%s, err:%v", got, err)
 	}
 
 	c.tag = "null"
-	if c.attribute() != "NULL" {
-		t.Fatalf("error column attribute. result:%s", c.attribute())
+	if got, err := c.attribute(); err != nil || got != "NULL COMMENT ''" {
+		t.Fatalf("error column attribute. result:%s, err:%v", got, err)
 	}
 
 	c.tag = "default=0"
-	if c.attribute() != "NOT NULL DEFAULT 0" {
-		t.Fatalf("error column attribute. result:%s", c.attribute())
+	if got, err := c.attribute(); err != nil || got != "NOT NULL DEFAULT 0 COMMENT ''" {
+		t.Fatalf("error column attribute. result:%s, err:%v", got, err)
 	}
 
 	c.tag = "auto"
-	if c.attribute() != "NOT NULL AUTO_INCREMENT" {
-		t.Fatalf("error column attribute. result:%s", c.attribute())
+	if got, err := c.attribute(); err != nil || got != "NOT NULL AUTO_INCREMENT COMMENT ''" {
+		t.Fatalf("error column attribute. result:%s, err:%v", got, err)
+	}
+}
+
+func TestAttribute_Dialects(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		tag      string
+		nullable bool
+		want     string
+		wantErr  error
+	}{
+		{
+			name:    "[Normal] MySQL escapes the comment",
+			dialect: mysql.MySQL{},
+			tag:     "default=CURRENT_TIMESTAMP,update=CURRENT_TIMESTAMP,comment=it's",
+			want:    "NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'it''s'",
+		},
+		{
+			name:     "[Normal] MySQL nullable Go type",
+			dialect:  mysql.MySQL{},
+			nullable: true,
+			want:     "NULL COMMENT 'c'",
+		},
+		{
+			name:     "[Normal] notnull overrides nullable Go type",
+			dialect:  mysql.MySQL{},
+			tag:      "notnull",
+			nullable: true,
+			want:     "NOT NULL COMMENT 'c'",
+		},
+		{
+			name:    "[Normal] SQLite omits comment",
+			dialect: sqlite.SQLite{},
+			tag:     "default=0,comment=count",
+			want:    "NOT NULL DEFAULT 0",
+		},
+		{
+			name:    "[Normal] PostgreSQL identity",
+			dialect: postgres.PostgreSQL{},
+			tag:     "auto",
+			want:    "NOT NULL GENERATED BY DEFAULT AS IDENTITY",
+		},
+		{
+			name:    "[Normal] SQLite ignores ON UPDATE",
+			dialect: sqlite.SQLite{},
+			tag:     "default=CURRENT_TIMESTAMP,update=CURRENT_TIMESTAMP",
+			want:    "NOT NULL DEFAULT CURRENT_TIMESTAMP",
+		},
+		{
+			name:    "[Normal] PostgreSQL ignores ON UPDATE",
+			dialect: postgres.PostgreSQL{},
+			tag:     "update=CURRENT_TIMESTAMP",
+			want:    "NOT NULL",
+		},
+		{
+			name:    "[Error] null and notnull",
+			dialect: mysql.MySQL{},
+			tag:     "null,notnull",
+			wantErr: ErrInvalidTag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := column{name: "c", tag: tt.tag, nullable: tt.nullable, dialect: tt.dialect}
+			got, err := c.attribute()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch error want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch want=%s, got=%s", tt.want, got)
+			}
+		})
 	}
 }
 
@@ -80,7 +158,7 @@ func TestToSQL(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		want := "`id` BIGINT NOT NULL"
+		want := "`id` BIGINT NOT NULL COMMENT 'id'"
 		if want != got {
 			t.Fatalf("mismatch: want=%s, got=%s", want, got)
 		}
@@ -97,7 +175,7 @@ func TestToSQL(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		want := "`id` BIGINT unsigned NOT NULL"
+		want := "`id` BIGINT unsigned NOT NULL COMMENT 'id'"
 		if want != got {
 			t.Fatalf("mismatch: want=%s, got=%s", want, got)
 		}
@@ -115,7 +193,7 @@ func TestToSQL(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		want := "`description` VARCHAR(20) NULL"
+		want := "`description` VARCHAR(20) NULL COMMENT 'description'"
 		if want != got {
 			t.Fatalf("mismatch: want=%s, got=%s", want, got)
 		}
@@ -132,7 +210,7 @@ func TestToSQL(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		want := "`comment` TEXT NULL"
+		want := "`comment` TEXT NULL COMMENT 'comment'"
 		if want != got {
 			t.Fatalf("mismatch: want=%s, got=%s", want, got)
 		}
@@ -172,6 +250,169 @@ func TestToSQL(t *testing.T) {
 	})
 }
 
+func TestToSQL_Decimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       dialect.Dialect
+		tag     string
+		want    string
+		wantErr error
+	}{
+		{name: "[Normal] precision and scale tags", d: mysql.MySQL{}, tag: "precision=10,scale=2", want: "`price` DECIMAL(10,2) NOT NULL COMMENT 'price'"},
+		{name: "[Normal] type tag with precision and scale", d: mysql.MySQL{}, tag: "type=decimal(10,2)", want: "`price` DECIMAL(10,2) NOT NULL COMMENT 'price'"},
+		{name: "[Normal] type tag with precision", d: mysql.MySQL{}, tag: "type=DECIMAL(5)", want: "`price` DECIMAL(5,0) NOT NULL COMMENT 'price'"},
+		{name: "[Normal] type tag and scale tag", d: mysql.MySQL{}, tag: "type=numeric,precision=8,scale=3", want: "`price` DECIMAL(8,3) NOT NULL COMMENT 'price'"},
+		{name: "[Normal] NUMERIC of SQLite", d: sqlite.SQLite{}, tag: "type=decimal(10,2)", want: "`price` NUMERIC(10,2) NOT NULL"},
+		{name: "[Error] scale is greater than precision", d: mysql.MySQL{}, tag: "precision=2,scale=3", wantErr: mysql.ErrInvalidType},
+		{name: "[Error] precision is not a number", d: mysql.MySQL{}, tag: "type=decimal(a,2)", wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := column{
+				typeName: "string",
+				name:     "price",
+				tag:      tt.tag,
+				dialect:  tt.d,
+			}
+			got, err := c.ToSQL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch: want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch: want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestToSQL_Enum(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       dialect.Dialect
+		tag     string
+		want    string
+		wantErr error
+	}{
+		{name: "[Normal] enum tag", d: mysql.MySQL{}, tag: "enum=active|banned|deleted", want: "`status` ENUM('active','banned','deleted') NOT NULL COMMENT 'status'"},
+		{name: "[Normal] set tag", d: mysql.MySQL{}, tag: "set=read|write,null", want: "`status` SET('read','write') NULL COMMENT 'status'"},
+		{name: "[Normal] CHECK constraint of SQLite", d: sqlite.SQLite{}, tag: "enum=active|banned", want: "`status` TEXT CHECK (`status` IN ('active','banned')) NOT NULL"},
+		{name: "[Error] enum tag with type tag", d: mysql.MySQL{}, tag: "enum=active,type=text", wantErr: ErrInvalidTag},
+		{name: "[Error] enum tag without values", d: mysql.MySQL{}, tag: "enum=", wantErr: ErrInvalidTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := column{
+				typeName: "string",
+				name:     "status",
+				tag:      tt.tag,
+				dialect:  tt.d,
+			}
+			got, err := c.ToSQL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch: want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch: want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestToSQL_Generated(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       dialect.Dialect
+		tag     string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "[Normal] stored column of MySQL",
+			d:    mysql.MySQL{},
+			tag:  "generated=CONCAT(first_name,' ',last_name),stored",
+			want: "`full_name` VARCHAR(191) GENERATED ALWAYS AS (CONCAT(first_name,' ',last_name)) STORED NOT NULL COMMENT 'full_name'",
+		},
+		{
+			name: "[Normal] virtual column of SQLite",
+			d:    sqlite.SQLite{},
+			tag:  "generated=json_extract(data, '$.name'),null",
+			want: "`full_name` TEXT GENERATED ALWAYS AS (json_extract(data, '$.name')) VIRTUAL NULL",
+		},
+		{
+			name: "[Normal] stored column of PostgreSQL",
+			d:    postgres.PostgreSQL{},
+			tag:  "generated=first_name || ' ' || last_name,stored",
+			want: `"full_name" TEXT GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED NOT NULL`,
+		},
+		{
+			name: "[Normal] persisted column of SQL Server",
+			d:    sqlserver.SQLServer{},
+			tag:  "generated=first_name + ' ' + last_name,stored",
+			want: "[full_name] AS (first_name + ' ' + last_name) PERSISTED NOT NULL",
+		},
+		{
+			name: "[Normal] computed column of SQL Server",
+			d:    sqlserver.SQLServer{},
+			tag:  "generated=first_name + ' ' + last_name",
+			want: "[full_name] AS (first_name + ' ' + last_name)",
+		},
+		{name: "[Error] virtual column of PostgreSQL", d: postgres.PostgreSQL{}, tag: "generated=a+b", wantErr: postgres.ErrUnsupportedAttribute},
+		{name: "[Error] dialect does not support generated column", d: mock.SQLMock{}, tag: "generated=a+b", wantErr: ErrInvalidTag},
+		{name: "[Error] generated column with default", d: mysql.MySQL{}, tag: "generated=a+b,default=0", wantErr: ErrInvalidTag},
+		{name: "[Error] stored without generated", d: mysql.MySQL{}, tag: "stored", wantErr: ErrInvalidTag},
+		{name: "[Error] stored and virtual", d: mysql.MySQL{}, tag: "generated=a+b,stored,virtual", wantErr: ErrInvalidTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := column{
+				typeName: "string",
+				name:     "full_name",
+				tag:      normalizeTag(tt.tag),
+				dialect:  tt.d,
+			}
+			got, err := c.ToSQL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch: want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch: want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestToSQL_Collation(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       dialect.Dialect
+		tag     string
+		want    string
+		wantErr error
+	}{
+		{name: "[Normal] MySQL", d: mysql.MySQL{}, tag: "size=64,charset=ascii,collate=ascii_bin", want: "`token` VARCHAR(64) CHARACTER SET ascii COLLATE ascii_bin NOT NULL COMMENT 'token'"},
+		{name: "[Normal] SQLite", d: sqlite.SQLite{}, tag: "collate=nocase", want: "`token` TEXT COLLATE NOCASE NOT NULL"},
+		{name: "[Error] collation does not belong to the charset", d: mysql.MySQL{}, tag: "charset=ascii,collate=utf8mb4_bin", wantErr: mysql.ErrInvalidCollation},
+		{name: "[Error] dialect does not support collation", d: postgres.PostgreSQL{}, tag: "collate=C", wantErr: ErrInvalidTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := column{
+				typeName: "string",
+				name:     "token",
+				tag:      tt.tag,
+				dialect:  tt.d,
+			}
+			got, err := c.ToSQL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch: want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch: want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_column_Name(t *testing.T) {
 	type fields struct {
 		name     string
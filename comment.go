@@ -0,0 +1,161 @@
+package ddlmaker
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// docComments holds doc comments of struct types and their fields read from Go source files.
+// The nil value has no doc comments.
+type docComments struct {
+	// packages maps import path into doc comments of the package.
+	// The key of doc comments is type name or <type name>.<field name>.
+	packages map[string]map[string]string
+}
+
+// newDocComments return empty docComments. Packages are parsed when they are looked up.
+func newDocComments() *docComments {
+	return &docComments{packages: make(map[string]map[string]string)}
+}
+
+// typeDoc returns doc comment of the named struct type.
+func (dc *docComments) typeDoc(rt reflect.Type) (string, error) {
+	return dc.lookup(rt, rt.Name())
+}
+
+// fieldDoc returns doc comment of the field of the named struct type.
+func (dc *docComments) fieldDoc(rt reflect.Type, field string) (string, error) {
+	return dc.lookup(rt, rt.Name()+"."+field)
+}
+
+func (dc *docComments) lookup(rt reflect.Type, key string) (string, error) {
+	if dc == nil || rt.Name() == "" {
+		return "", nil
+	}
+	docs, ok := dc.packages[rt.PkgPath()]
+	if !ok {
+		var err error
+		if docs, err = parseDocComments(rt.PkgPath()); err != nil {
+			return "", fmt.Errorf("error parse doc comments of %s: %w", rt.PkgPath(), err)
+		}
+		dc.packages[rt.PkgPath()] = docs
+	}
+	return docs[key], nil
+}
+
+// parseDocComments parses Go source files of the package selected by build constraints, and returns
+// doc comments of struct types and their fields. Types of package main are looked up in the working
+// directory. Test files are parsed only for types that are not declared by the other files, because
+// such types are declared by tests.
+func parseDocComments(pkgPath string) (map[string]string, error) {
+	pkg, err := importPackage(pkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]string)
+	declared := make(map[string]bool)
+	fset := token.NewFileSet()
+	parse := func(files []string, fallback bool) error {
+		for _, file := range files {
+			f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, file), nil, parser.ParseComments)
+			if err != nil {
+				return err
+			}
+			for _, ts := range structTypes(f) {
+				if fallback && declared[ts.spec.Name.Name] {
+					continue
+				}
+				declared[ts.spec.Name.Name] = true
+				addDocComments(docs, ts)
+			}
+		}
+		return nil
+	}
+
+	if strings.HasSuffix(pkgPath, "_test") {
+		// types of external test packages are declared only in their test files
+		return docs, parse(pkg.XTestGoFiles, false)
+	}
+	if err := parse(append(append([]string{}, pkg.GoFiles...), pkg.CgoFiles...), false); err != nil {
+		return nil, err
+	}
+	if err := parse(pkg.TestGoFiles, true); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// structType is the struct type declared in the Go source file with its doc comment.
+type structType struct {
+	spec *ast.TypeSpec
+	doc  *ast.CommentGroup
+}
+
+// structTypes returns struct types declared in the file.
+func structTypes(f *ast.File) []structType {
+	var types []structType
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gen.Specs {
+			ts := s.(*ast.TypeSpec)
+			if _, ok := ts.Type.(*ast.StructType); !ok {
+				continue
+			}
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			types = append(types, structType{spec: ts, doc: doc})
+		}
+	}
+	return types
+}
+
+// addDocComments adds doc comments of the struct type and its fields into docs.
+func addDocComments(docs map[string]string, ts structType) {
+	name := ts.spec.Name.Name
+	if text := commentText(ts.doc); text != "" {
+		docs[name] = text
+	}
+	for _, field := range ts.spec.Type.(*ast.StructType).Fields.List {
+		text := commentText(field.Doc)
+		if text == "" {
+			continue
+		}
+		for _, fieldName := range field.Names {
+			docs[name+"."+fieldName.Name] = text
+		}
+	}
+}
+
+// importPackage returns the package that has source files selected by build constraints.
+func importPackage(pkgPath string) (*build.Package, error) {
+	if pkgPath == "main" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		return build.ImportDir(dir, 0)
+	}
+	// types of external test packages are declared in the directory of the package
+	return build.Import(strings.TrimSuffix(pkgPath, "_test"), ".", 0)
+}
+
+// commentText returns the text of the comment in a single line.
+func commentText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(cg.Text()), " ")
+}
@@ -1,14 +1,172 @@
 package ddlmaker
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/nao1215/nameconv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// LayoutSingle writes all tables into OutFilePath
+	LayoutSingle = "single"
+	// LayoutTable writes each table into <table>.sql with header and footer in OutFilePath directory
+	LayoutTable = "table"
+	// LayoutStatement writes each statement into a numbered file in OutFilePath directory
+	LayoutStatement = "statement"
+
+	// NamingSnake converts field names into snake_case column names (default)
+	NamingSnake = "snake"
+	// NamingCamel converts field names into camelCase column names
+	NamingCamel = "camel"
+	// NamingPascal converts field names into PascalCase column names
+	NamingPascal = "pascal"
+	// NamingFlat converts field names into flatcase column names
+	NamingFlat = "flat"
+)
+
+var (
+	// ErrUnsupportedConfig means the format of the config file is not supported
+	ErrUnsupportedConfig = errors.New("config file format is not supported")
+	// ErrInvalidConfig means the value of the config is invalid
+	ErrInvalidConfig = errors.New("invalid config")
+)
+
 // Config set user environment
 type Config struct {
-	OutFilePath string
-	DB          DBConfig
+	OutFilePath string   `json:"out" yaml:"out" toml:"out"`
+	DB          DBConfig `json:"db" yaml:"db" toml:"db"`
+	// DocComments fills column and table comments with doc comments of struct fields and types
+	// when comment tag or TableOptions().Comment is not specified. Source files of the package
+	// declaring the struct are parsed.
+	DocComments bool `json:"doc_comments" yaml:"doc_comments" toml:"doc_comments"`
+	// Layout is the output layout. Empty means LayoutSingle.
+	Layout string `json:"layout" yaml:"layout" toml:"layout"`
+	// Naming is the strategy to convert field names into column names. Empty means NamingSnake.
+	Naming string `json:"naming" yaml:"naming" toml:"naming"`
+	// Types maps fully qualified Go types (e.g. github.com/google/uuid.UUID) into SQL types.
+	// They take precedence over types of the dialect.
+	Types map[string]string `json:"types" yaml:"types" toml:"types"`
+	// Lint maps lint rule IDs (e.g. missing-primary-key) into severities (off, warning or error).
+	// Rules are warnings by default, and only rules of error stop the generation.
+	Lint map[string]string `json:"lint" yaml:"lint" toml:"lint"`
+	// Header and Footer override HeaderTemplate and FooterTemplate of the dialect if they are not empty
+	Header string `json:"header" yaml:"header" toml:"header"`
+	Footer string `json:"footer" yaml:"footer" toml:"footer"`
+	// Tables selects tables to be generated by their names
+	Tables TableFilter `json:"tables" yaml:"tables" toml:"tables"`
+	// Logger logs progress of the generation. Nil means the standard logger of log package.
+	Logger Logger `json:"-" yaml:"-" toml:"-"`
 }
 
 // DBConfig set user db environment
 type DBConfig struct {
-	Driver  string
-	Engine  string
-	Charset string
+	Driver  string `json:"driver" yaml:"driver" toml:"driver"`
+	Engine  string `json:"engine" yaml:"engine" toml:"engine"`
+	Charset string `json:"charset" yaml:"charset" toml:"charset"`
+}
+
+// TableFilter selects tables by patterns of path.Match (e.g. user_*).
+// Tables matching Include (all tables if it is empty) and not matching Exclude are selected.
+type TableFilter struct {
+	Include []string `json:"include" yaml:"include" toml:"include"`
+	Exclude []string `json:"exclude" yaml:"exclude" toml:"exclude"`
+}
+
+// LoadConfig reads the config file. The format is decided by the extension
+// (.json, .yaml, .yml or .toml). Unknown keys are an error.
+func LoadConfig(filename string) (Config, error) {
+	var conf Config
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".json", ".yaml", ".yml", ".toml":
+	default:
+		return conf, fmt.Errorf("%w: %s", ErrUnsupportedConfig, filename)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return conf, fmt.Errorf("error read config: %w", err)
+	}
+
+	switch ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&conf)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&conf)
+	case ".toml":
+		var md toml.MetaData
+		md, err = toml.Decode(string(data), &conf)
+		if err == nil && len(md.Undecoded()) > 0 {
+			err = fmt.Errorf("unknown keys %v", md.Undecoded())
+		}
+	}
+	if err != nil {
+		return conf, fmt.Errorf("error decode config %s: %w", filename, err)
+	}
+
+	if err := conf.validate(); err != nil {
+		return conf, err
+	}
+	return conf, nil
+}
+
+// validate returns error if the layout, the naming strategy, the lint rules or the table filters are invalid.
+func (conf Config) validate() error {
+	switch conf.Layout {
+	case "", LayoutSingle, LayoutTable, LayoutStatement:
+	default:
+		return fmt.Errorf("%w: unknown layout %s", ErrInvalidConfig, conf.Layout)
+	}
+	if _, err := conf.columnName("ID"); err != nil {
+		return err
+	}
+	if err := validateLint(conf.Lint); err != nil {
+		return err
+	}
+	for _, pattern := range append(conf.Tables.Include, conf.Tables.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: table pattern %s: %v", ErrInvalidConfig, pattern, err)
+		}
+	}
+	return nil
+}
+
+// columnName converts the field name into the column name by the naming strategy.
+func (conf Config) columnName(field string) (string, error) {
+	switch conf.Naming {
+	case "", NamingSnake:
+		return nameconv.ToSnakeCase(field), nil
+	case NamingCamel:
+		return nameconv.ToCamelCase(field), nil
+	case NamingPascal:
+		return nameconv.ToPascalCase(field), nil
+	case NamingFlat:
+		return nameconv.ToFlatCase(field), nil
+	}
+	return "", fmt.Errorf("%w: unknown naming %s", ErrInvalidConfig, conf.Naming)
+}
+
+// selected reports whether the table is selected by the table filters.
+func (f TableFilter) selected(table string) bool {
+	match := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, table); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return (len(f.Include) == 0 || match(f.Include)) && !match(f.Exclude)
 }
@@ -0,0 +1,145 @@
+package ddlmaker
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadConfig(t *testing.T) {
+	want := Config{
+		OutFilePath: "./sql/schema.sql",
+		DB:          DBConfig{Driver: "mysql", Engine: "InnoDB", Charset: "utf8mb4"},
+		Naming:      NamingCamel,
+		Types:       map[string]string{"github.com/google/uuid.UUID": "BINARY(16)"},
+		Lint:        map[string]string{"missing-primary-key": "off"},
+		Header:      "SET foreign_key_checks=0;\n",
+		Tables:      TableFilter{Include: []string{"user*"}, Exclude: []string{"user_log"}},
+	}
+
+	for _, filename := range []string{"ddl-maker.json", "ddl-maker.yaml", "ddl-maker.toml"} {
+		t.Run("[Normal] "+filename, func(t *testing.T) {
+			got, err := LoadConfig("./testdata/config/" + filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		wantErr  error
+	}{
+		{name: "[Error] unsupported format", filename: "ddl-maker.ini", wantErr: ErrUnsupportedConfig},
+		{name: "[Error] invalid naming", filename: "invalid.yaml", wantErr: ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadConfig("./testdata/config/" + tt.filename); !errors.Is(err, tt.wantErr) {
+				t.Errorf("mismatch want=%v, got=%v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("[Error] unknown key", func(t *testing.T) {
+		if _, err := LoadConfig("./testdata/config/unknown.json"); err == nil {
+			t.Error("unknown key is not an error")
+		}
+	})
+}
+
+type UserLog struct {
+	ID      uint64
+	Message string
+}
+
+type UserSetting struct {
+	ID        uint64 `ddl:"pk"`
+	UserID    uint64
+	CreatedAt Money
+}
+
+type UserGroup struct {
+	GroupID uint64 `ddl:"pk"`
+}
+
+type UserMember struct {
+	ID      uint64 `ddl:"pk"`
+	GroupID uint64
+}
+
+func (UserMember) References() map[string]interface{} {
+	return map[string]interface{}{"GroupID": UserGroup{}}
+}
+
+func TestDDLMaker_Config(t *testing.T) {
+	conf := Config{
+		DB:     DBConfig{Driver: "mysql"},
+		Naming: NamingCamel,
+		Types:  map[string]string{"github.com/mnhkahn/ddl-maker.Money": "DECIMAL(12,2)"},
+		Header: "-- schema\n",
+		Footer: "-- end\n",
+		Tables: TableFilter{Include: []string{"user_*"}, Exclude: []string{"user_log"}},
+	}
+	dm, err := New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.AddStruct(UserLog{}, UserSetting{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got bytes.Buffer
+	if err := dm.generate(&got); err != nil {
+		t.Fatal(err)
+	}
+	want := "-- schema\n" +
+		"\n" +
+		"DROP TABLE IF EXISTS `user_setting`;\n" +
+		"\n" +
+		"CREATE TABLE `user_setting` (\n" +
+		"    `id` BIGINT unsigned NOT NULL COMMENT 'id',\n" +
+		"    `userId` BIGINT unsigned NOT NULL COMMENT 'userId',\n" +
+		"    `createdAt` DECIMAL(12,2) NOT NULL COMMENT 'createdAt',\n" +
+		"    PRIMARY KEY (`id`)\n" +
+		");\n" +
+		"\n" +
+		"-- end\n"
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+
+	t.Run("[Normal] references are named by the naming strategy", func(t *testing.T) {
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, Naming: NamingCamel})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(UserGroup{}, UserMember{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.parse(); err != nil {
+			t.Fatal(err)
+		}
+		got := dm.Tables[1].ForeignKeys()[0].ToSQL()
+		want := "FOREIGN KEY (`groupId`) REFERENCES `user_group` (`groupId`)"
+		if got != want {
+			t.Errorf("mismatch want=%s, got=%s", want, got)
+		}
+	})
+
+	t.Run("[Error] invalid layout", func(t *testing.T) {
+		_, err := New(Config{DB: DBConfig{Driver: "mysql"}, Layout: "zip"})
+		if !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("mismatch want=%v, got=%v", ErrInvalidConfig, err)
+		}
+	})
+}
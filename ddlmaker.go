@@ -2,14 +2,17 @@ package ddlmaker
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/migrate"
+	"github.com/mnhkahn/ddl-maker/query"
 	"github.com/pkg/errors"
 )
 
@@ -23,6 +26,18 @@ const (
 var (
 	// ErrIgnoreField is Ignore Field Error
 	ErrIgnoreField = errors.New("error ignore this field")
+	// ErrInvalidTag means the value of ddl tag is invalid
+	ErrInvalidTag = errors.New("invalid ddl tag")
+	// ErrDuplicatePrimaryKey means the primary key is declared by both tags and PrimaryKey method
+	ErrDuplicatePrimaryKey = errors.New("primary key is declared twice")
+	// ErrDuplicateIndex means the same index name is declared more than once
+	ErrDuplicateIndex = errors.New("index is declared twice")
+	// ErrDuplicateCheck means the same check constraint name is declared more than once
+	ErrDuplicateCheck = errors.New("check constraint is declared twice")
+	// ErrDuplicateColumn means the same column name is generated from more than one field
+	ErrDuplicateColumn = errors.New("column is declared twice")
+	// ErrNoPrimaryKey means the struct referred by References does not declare the primary key
+	ErrNoPrimaryKey = errors.New("primary key is not declared")
 )
 
 // DDLMaker is the model for generating DDL from golang structures.
@@ -36,19 +51,33 @@ type DDLMaker struct {
 	Structs []interface{}
 	// Tables is interface to generate tables for each DB (e.g. MySQL, PostgreSQL)
 	Tables []dialect.Table
+	// types maps Go types into SQL types. It takes precedence over types of the dialect.
+	types dialect.Types
+	// deferred is foreign keys of cycles that are added after all tables are created
+	deferred []deferredForeignKey
+	// parsed is true if Tables are parsed from Structs. AddStruct and RegisterType reset it.
+	parsed bool
 }
 
 // New creates a DDLMaker and returns it.
+// Types of the config are registered as RegisterType.
 func New(conf Config) (*DDLMaker, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
 	d, err := dialect.New(conf.DB.Driver, conf.DB.Engine, conf.DB.Charset)
 	if err != nil {
 		return nil, fmt.Errorf("error dialect.New(): %w", err)
 	}
 
-	return &DDLMaker{
+	dm := &DDLMaker{
 		config:  conf,
 		Dialect: d,
-	}, nil
+	}
+	for goType, sqlType := range conf.Types {
+		dm.RegisterType(goType, dialect.Fixed(sqlType))
+	}
+	return dm, nil
 }
 
 // AddStruct add the structure to be converted in DDLMaker.
@@ -71,13 +100,34 @@ func (dm *DDLMaker) AddStruct(ss ...interface{}) error {
 		dm.Structs = append(dm.Structs, s)
 		pkgs[structName] = true
 	}
+	dm.parsed = false
 
 	return nil
 }
 
+// RegisterType maps the fully qualified Go type (e.g. github.com/google/uuid.UUID)
+// into SQL type returned by fn. Pointers of the type are mapped as well.
+func (dm *DDLMaker) RegisterType(goType string, fn dialect.TypeFunc) {
+	if dm.types == nil {
+		dm.types = make(dialect.Types)
+	}
+	dm.types.Register(goType, fn)
+	dm.parsed = false
+}
+
+// Schema is the result of the generation.
+type Schema struct {
+	// Tables are the generated tables in the order written
+	Tables []dialect.Table
+	// Statements are the generated statements including header and footer in the order written
+	Statements []string
+	// Warnings are diagnostics of Lint of SeverityWarning, which do not stop the generation
+	Warnings []string
+}
+
 // Generate ddl file
 func (dm *DDLMaker) GenerateJSON(json string) ([]byte, error) {
-	log.Printf("start generate %s \n", dm.config.OutFilePath)
+	dm.logger().Info("start generate", "path", dm.config.OutFilePath)
 	err := dm.parseJSON(json)
 	if err != nil {
 		return nil, err // This pass will not go through.
@@ -90,64 +140,176 @@ func (dm *DDLMaker) GenerateJSON(json string) ([]byte, error) {
 		return nil, fmt.Errorf("error generate: %w", err)
 	}
 
-	log.Printf("done generate %s \n", dm.config.OutFilePath)
+	dm.logger().Info("done generate", "path", dm.config.OutFilePath)
 
 	return res.Bytes(), nil
 }
 
-// Generate ddl file
+// Generate ddl file. See GenerateFiles for layouts of the output and diagnostics of Lint.
 func (dm *DDLMaker) Generate() error {
-	log.Printf("start generate %s \n", dm.config.OutFilePath)
-	err := dm.parse()
-	if err != nil {
-		return err // This pass will not go through.
-	}
+	_, err := dm.GenerateFiles(context.Background())
+	return err
+}
 
-	file, err := os.Create(dm.config.OutFilePath)
+// GenerateTo writes DDL of added structs into w, and returns the generated schema.
+// Structs are parsed unless they are parsed after the last AddStruct. It stops when ctx is done.
+// Diagnostics of Lint of SeverityWarning are returned as Warnings of the schema. If any diagnostic
+// is SeverityError, nothing is written and *LintError is returned.
+func (dm *DDLMaker) GenerateTo(ctx context.Context, w io.Writer) (*Schema, error) {
+	dm.logger().Info("start generate")
+	if err := dm.parseIfNeeded(); err != nil {
+		return nil, err
+	}
+	warnings, err := dm.checkLint()
 	if err != nil {
-		return fmt.Errorf("error create ddl file: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
-	err = dm.generate(file)
+	schema, err := dm.render(ctx, w)
 	if err != nil {
-		return fmt.Errorf("error generate: %w", err)
+		return nil, fmt.Errorf("error generate: %w", err)
+	}
+	for _, d := range warnings {
+		schema.Warnings = append(schema.Warnings, d.String())
 	}
 
-	log.Printf("done generate %s \n", dm.config.OutFilePath)
+	dm.logger().Info("done generate", "tables", len(schema.Tables), "statements", len(schema.Statements))
+	return schema, nil
+}
 
-	return nil
+// Diff returns statements that migrate the schema of from into the schema of added structs.
+func (dm *DDLMaker) Diff(from []dialect.Table) ([]string, error) {
+	if err := dm.parseIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	stmts, err := migrate.Diff(dm.Dialect, from, dm.Tables)
+	if err != nil {
+		return nil, fmt.Errorf("error diff: %w", err)
+	}
+	return stmts, nil
 }
 
 // generate is helper method that generate ddl file
 func (dm *DDLMaker) generate(w io.Writer) error {
-	header, err := template.New("header").Parse(dm.Dialect.HeaderTemplate())
-	if err != nil {
-		return fmt.Errorf("error parse header template: %w", err)
-	}
+	_, err := dm.render(context.Background(), w)
+	return err
+}
 
-	footer, err := template.New("footer").Parse(dm.Dialect.FooterTemplate())
+// render writes header, tables, foreign keys of cycles and footer into w, and returns the schema of them.
+func (dm *DDLMaker) render(ctx context.Context, w io.Writer) (*Schema, error) {
+	tmpls, err := dm.parseTemplates()
 	if err != nil {
-		return fmt.Errorf("error parse footer template: %w", err)
+		return nil, err
 	}
 
-	tmpl, err := template.New("ddl").Parse(dm.Dialect.TableTemplate())
-	if err != nil {
-		return fmt.Errorf("error parse ddl template: %w", err)
+	schema := &Schema{}
+	write := func(sql string) error {
+		if _, err := io.WriteString(w, sql); err != nil {
+			return err
+		}
+		schema.Statements = append(schema.Statements, query.SplitStatements(sql)...)
+		return nil
 	}
 
-	if err := header.Execute(w, nil); err != nil {
-		return fmt.Errorf("template header execute error: %w", err)
+	header, err := execute(tmpls.header, nil)
+	if err == nil {
+		err = write(header)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("template header execute error: %w", err)
 	}
 	for _, table := range dm.Tables {
-		err := tmpl.Execute(w, table)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sql, err := execute(tmpls.table, table)
+		if err == nil {
+			err = write(sql)
+		}
 		if err != nil {
-			return fmt.Errorf("template execute error: %w", err)
+			return nil, fmt.Errorf("template execute error: %w", err)
 		}
+		schema.Tables = append(schema.Tables, table)
+	}
+	if err := write(foreignKeysSQL(dm.deferred)); err != nil {
+		return nil, fmt.Errorf("error write foreign keys: %w", err)
 	}
-	if err := footer.Execute(w, nil); err != nil {
-		return fmt.Errorf("template footer execute error: %w", err)
+	footer, err := execute(tmpls.footer, nil)
+	if err == nil {
+		err = write(footer)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("template footer execute error: %w", err)
 	}
 
-	return nil
+	return schema, nil
+}
+
+// templates are header, footer and table templates used to generate DDL.
+type templates struct {
+	header *template.Template
+	footer *template.Template
+	table  *template.Template
+}
+
+// parseTemplates parses templates of the dialect. Header and footer of the config take precedence.
+func (dm *DDLMaker) parseTemplates() (templates, error) {
+	var tmpls templates
+	var err error
+
+	headerTemplate := dm.config.Header
+	if headerTemplate == "" {
+		headerTemplate = dm.Dialect.HeaderTemplate()
+	}
+	if tmpls.header, err = template.New("header").Parse(headerTemplate); err != nil {
+		return tmpls, fmt.Errorf("error parse header template: %w", err)
+	}
+
+	footerTemplate := dm.config.Footer
+	if footerTemplate == "" {
+		footerTemplate = dm.Dialect.FooterTemplate()
+	}
+	if tmpls.footer, err = template.New("footer").Parse(footerTemplate); err != nil {
+		return tmpls, fmt.Errorf("error parse footer template: %w", err)
+	}
+
+	if tmpls.table, err = template.New("ddl").Parse(dm.Dialect.TableTemplate()); err != nil {
+		return tmpls, fmt.Errorf("error parse ddl template: %w", err)
+	}
+	return tmpls, nil
+}
+
+// execute returns the text of the template applied to data.
+func execute(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// logger returns Logger of the config, or the logger that writes to the standard logger.
+func (dm *DDLMaker) logger() Logger {
+	if dm.config.Logger != nil {
+		return dm.config.Logger
+	}
+	return stdLogger{}
+}
+
+// Logger logs progress of the generation. *slog.Logger satisfies it.
+type Logger interface {
+	Info(msg string, args ...any)
+}
+
+// stdLogger writes messages and key-value pairs to the standard logger.
+type stdLogger struct{}
+
+func (stdLogger) Info(msg string, args ...any) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	log.Println(b.String())
 }
@@ -2,18 +2,23 @@ package ddlmaker
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/nao1215/ddl-maker/dialect"
-	"github.com/nao1215/ddl-maker/dialect/mock"
-	"github.com/nao1215/ddl-maker/dialect/mysql"
-	"github.com/nao1215/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mock"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlserver"
+	"github.com/mnhkahn/ddl-maker/migrate"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,7 +41,7 @@ type TestTwo struct {
 	UpdatedAt time.Time
 }
 
-type unknown int
+type unknown struct{}
 type TestThree struct {
 	ID unknown
 }
@@ -118,7 +123,7 @@ func TestAddStruct2(t *testing.T) {
 		if got == nil {
 			t.Fatal("add struct error did not occure")
 		}
-		want := "github.com/nao1215/ddl-maker.TestOne is already added"
+		want := "github.com/mnhkahn/ddl-maker.TestOne is already added"
 		if want != got.Error() {
 			t.Errorf("mismatch want:%s, got:%s", want, got.Error())
 		}
@@ -131,10 +136,10 @@ func TestGenerate(t *testing.T) {
 DROP TABLE IF EXISTS %s;
 
 CREATE TABLE %s (
-    %s BIGINT unsigned NOT NULL,
-    %s VARCHAR(191) NOT NULL,
-    %s DATETIME NOT NULL,
-    %s DATETIME NOT NULL,
+    %s BIGINT unsigned NOT NULL COMMENT 'id',
+    %s VARCHAR(191) NOT NULL COMMENT 'name',
+    %s DATETIME NOT NULL COMMENT 'created_at',
+    %s DATETIME NOT NULL COMMENT 'updated_at',
     PRIMARY KEY (%s)
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4;
 
@@ -144,11 +149,11 @@ CREATE TABLE %s (
 DROP TABLE IF EXISTS %s;
 
 CREATE TABLE %s (
-    %s BIGINT unsigned NOT NULL,
-    %s BIGINT unsigned NOT NULL,
-    %s VARCHAR(191) NULL,
-    %s DATETIME NOT NULL,
-    %s DATETIME NOT NULL,
+    %s BIGINT unsigned NOT NULL COMMENT 'id',
+    %s BIGINT unsigned NOT NULL COMMENT 'test_one_id',
+    %s VARCHAR(191) NULL COMMENT 'comment',
+    %s DATETIME NOT NULL COMMENT 'created_at',
+    %s DATETIME NOT NULL COMMENT 'updated_at',
     PRIMARY KEY (%s, %s)
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4;
 
@@ -499,6 +504,245 @@ func TestDDLMaker_GenerateForSQLite(t *testing.T) {
 	})
 }
 
+type Account struct {
+	ID        int64  `ddl:"auto"`
+	Name      string `ddl:"size=100,comment=account's_name"`
+	Active    bool   `ddl:"default=true"`
+	CreatedAt time.Time
+}
+
+func (a Account) PrimaryKey() dialect.PrimaryKey {
+	return postgres.AddPrimaryKey("id")
+}
+
+type Session struct {
+	ID        int64 `ddl:"auto"`
+	AccountID int64
+	Token     string
+	Payload   []byte `ddl:"type=jsonb"`
+	ExpiredAt time.Time
+}
+
+func (s Session) PrimaryKey() dialect.PrimaryKey {
+	return postgres.AddPrimaryKey("id")
+}
+
+func (s Session) Indexes() dialect.Indexes {
+	return dialect.Indexes{
+		postgres.AddUniqueIndex("token_uniq_idx", "session", "token"),
+		postgres.AddIndex("expired_at_idx", "session", "expired_at"),
+	}
+}
+
+func (s Session) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{
+		postgres.AddForeignKey(
+			[]string{"account_id"},
+			[]string{"id"},
+			"account",
+			postgres.WithDeleteForeignKeyOption(postgres.ForeignKeyOptionCascade),
+		),
+	}
+}
+
+func TestDDLMaker_GenerateForPostgres(t *testing.T) {
+	t.Run("[Normal] generate ddl file for PostgreSQL", func(t *testing.T) {
+		dm, err := New(Config{
+			OutFilePath: "./testdata/postgres/test.sql",
+			DB: DBConfig{
+				Driver: "postgres",
+			},
+		})
+		if err != nil {
+			t.Fatal("error new maker", err)
+		}
+		defer os.Remove("./testdata/postgres/test.sql")
+
+		if err = dm.AddStruct(&Account{}, &Session{}); err != nil {
+			t.Fatal("error add struct", err)
+		}
+
+		if err = dm.Generate(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := os.ReadFile("./testdata/postgres/test.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := os.ReadFile("./testdata/postgres/golden.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+}
+
+type Report struct {
+	ID        int64 `ddl:"auto"`
+	AccountID int64
+	Title     string `ddl:"size=100"`
+	Body      string `ddl:"type=text,null"`
+	Published bool   `ddl:"default=0"`
+	CreatedAt time.Time
+}
+
+func (r Report) PrimaryKey() dialect.PrimaryKey {
+	return sqlserver.AddPrimaryKey("id")
+}
+
+func (r Report) Indexes() dialect.Indexes {
+	return dialect.Indexes{
+		sqlserver.AddIndex("created_at_idx", "report", "created_at"),
+	}
+}
+
+func (r Report) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{
+		sqlserver.AddForeignKey(
+			[]string{"account_id"},
+			[]string{"id"},
+			"account",
+			sqlserver.WithDeleteForeignKeyOption(sqlserver.ForeignKeyOptionCascade),
+		),
+	}
+}
+
+func TestDDLMaker_GenerateForSQLServer(t *testing.T) {
+	t.Run("[Normal] generate ddl file for SQL Server", func(t *testing.T) {
+		dm, err := New(Config{
+			OutFilePath: "./testdata/sqlserver/test.sql",
+			DB: DBConfig{
+				Driver: "sqlserver",
+			},
+		})
+		if err != nil {
+			t.Fatal("error new maker", err)
+		}
+		defer os.Remove("./testdata/sqlserver/test.sql")
+
+		if err = dm.AddStruct(&Report{}); err != nil {
+			t.Fatal("error add struct", err)
+		}
+
+		if err = dm.Generate(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := os.ReadFile("./testdata/sqlserver/test.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := os.ReadFile("./testdata/sqlserver/golden.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+}
+
+type recordLogger struct {
+	messages []string
+}
+
+func (l *recordLogger) Info(msg string, args ...any) {
+	l.messages = append(l.messages, msg)
+}
+
+func TestDDLMaker_GenerateTo(t *testing.T) {
+	t.Run("[Normal] write to writer and return schema", func(t *testing.T) {
+		logger := &recordLogger{}
+		dm, err := New(Config{DB: DBConfig{Driver: "sqlserver"}, Logger: logger})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(&Report{}); err != nil {
+			t.Fatal(err)
+		}
+
+		var got bytes.Buffer
+		schema, err := dm.GenerateTo(context.Background(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := os.ReadFile("./testdata/sqlserver/golden.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(string(want), got.String()); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+		if len(schema.Tables) != 1 || schema.Tables[0].Name() != "[report]" {
+			t.Errorf("mismatch tables: %v", schema.Tables)
+		}
+		wantStatements := []string{
+			"SET ANSI_NULLS ON;",
+			"SET QUOTED_IDENTIFIER ON;",
+			"IF OBJECT_ID(N'[report]', N'U') IS NOT NULL DROP TABLE [report];",
+		}
+		if diff := cmp.Diff(wantStatements, schema.Statements[:3]); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+		if diff := cmp.Diff([]string{"start generate", "done generate"}, logger.messages); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] parse structs added after generation", func(t *testing.T) {
+		dm, err := New(Config{DB: DBConfig{Driver: "postgres"}, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(&Account{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dm.GenerateTo(context.Background(), io.Discard); err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(&Session{}); err != nil {
+			t.Fatal(err)
+		}
+
+		schema, err := dm.GenerateTo(context.Background(), io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, table := range schema.Tables {
+			got = append(got, table.Name())
+		}
+		if diff := cmp.Diff([]string{`"account"`, `"session"`}, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Error] context is canceled", func(t *testing.T) {
+		dm, err := New(Config{DB: DBConfig{Driver: "sqlserver"}, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(&Report{}); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var got bytes.Buffer
+		if _, err := dm.GenerateTo(ctx, &got); !errors.Is(err, context.Canceled) {
+			t.Errorf("mismatch want=%v, got=%v", context.Canceled, err)
+		}
+	})
+}
+
 func TestJSON(t *testing.T) {
 	conf := Config{
 		DB: DBConfig{
@@ -514,3 +758,47 @@ func TestJSON(t *testing.T) {
 	assert.Nil(t, err)
 	t.Log(string(res))
 }
+
+func TestDDLMaker_Diff(t *testing.T) {
+	t.Run("[Normal] add column to existing table", func(t *testing.T) {
+		old, err := New(Config{DB: DBConfig{Driver: "mysql", Engine: "InnoDB", Charset: "utf8mb4"}})
+		if err != nil {
+			t.Fatal("error new maker", err)
+		}
+		if err = old.AddStruct(&TestOne{}); err != nil {
+			t.Fatal("error add struct", err)
+		}
+		if err = old.parse(); err != nil {
+			t.Fatal(err)
+		}
+
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql", Engine: "InnoDB", Charset: "utf8mb4"}})
+		if err != nil {
+			t.Fatal("error new maker", err)
+		}
+		if err = dm.AddStruct(&TestOne{}); err != nil {
+			t.Fatal("error add struct", err)
+		}
+		if err = dm.parse(); err != nil {
+			t.Fatal(err)
+		}
+		dm.Tables[0] = newTable("test_one", mysql.AddPrimaryKey("id"), nil,
+			append(dm.Tables[0].Columns(), newColumn("deleted_at", "time.Time", "null", dm.Dialect)), nil, dm.Dialect)
+
+		got, err := dm.Diff(old.Tables)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"ALTER TABLE `test_one` ADD COLUMN `deleted_at` DATETIME NULL COMMENT 'deleted_at';"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Error] dialect does not support migration", func(t *testing.T) {
+		dm := DDLMaker{Dialect: &mock.SQLMock{}}
+		if _, err := dm.Diff(nil); !errors.Is(err, migrate.ErrNotSupported) {
+			t.Errorf("mismatch want:%v, got:%v", migrate.ErrNotSupported, err)
+		}
+	})
+}
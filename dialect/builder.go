@@ -0,0 +1,162 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlserver"
+)
+
+// ErrBuilderNotSupported means the dialect does not have constructors of keys and indexes
+var ErrBuilderNotSupported = errors.New("dialect does not support building keys")
+
+// Builder creates keys and indexes by constructors of the dialect,
+// so that they can be declared without choosing the dialect package.
+type Builder interface {
+	PrimaryKey(columns []string) PrimaryKey
+	Index(name, table string, columns []string) Index
+	UniqueIndex(name, table string, columns []string) Index
+	// ForeignKey creates foreign key. name is the constraint name, which is used
+	// only by dialects that name foreign keys, and empty string means no name.
+	// onDelete and onUpdate are referential actions (e.g. CASCADE, SET NULL) and
+	// empty string means no action.
+	ForeignKey(name string, columns, refColumns []string, refTable, onDelete, onUpdate string) ForeignKey
+	Check(name, expression string) Check
+}
+
+// NewBuilder returns Builder of the dialect.
+func NewBuilder(d Dialect) (Builder, error) {
+	switch d.(type) {
+	case mysql.MySQL, *mysql.MySQL:
+		return mysqlBuilder{}, nil
+	case sqlite.SQLite, *sqlite.SQLite:
+		return sqliteBuilder{}, nil
+	case postgres.PostgreSQL, *postgres.PostgreSQL:
+		return postgresBuilder{}, nil
+	case sqlserver.SQLServer, *sqlserver.SQLServer:
+		return sqlserverBuilder{}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrBuilderNotSupported, d)
+}
+
+type mysqlBuilder struct{}
+
+func (mysqlBuilder) PrimaryKey(columns []string) PrimaryKey {
+	return mysql.AddPrimaryKey(columns...)
+}
+
+func (mysqlBuilder) Index(name, table string, columns []string) Index {
+	return mysql.AddIndex(name, columns...)
+}
+
+func (mysqlBuilder) UniqueIndex(name, table string, columns []string) Index {
+	return mysql.AddUniqueIndex(name, columns...)
+}
+
+func (mysqlBuilder) ForeignKey(name string, columns, refColumns []string, refTable, onDelete, onUpdate string) ForeignKey {
+	var options []mysql.ForeignKeyOption
+	if name != "" {
+		options = append(options, mysql.WithNameForeignKeyOption(name))
+	}
+	if onDelete != "" {
+		options = append(options, mysql.WithDeleteForeignKeyOption(mysql.ForeignKeyOptionType(onDelete)))
+	}
+	if onUpdate != "" {
+		options = append(options, mysql.WithUpdateForeignKeyOption(mysql.ForeignKeyOptionType(onUpdate)))
+	}
+	return mysql.AddForeignKey(columns, refColumns, refTable, options...)
+}
+
+func (mysqlBuilder) Check(name, expression string) Check {
+	return mysql.AddCheck(name, expression)
+}
+
+type sqliteBuilder struct{}
+
+func (sqliteBuilder) PrimaryKey(columns []string) PrimaryKey {
+	return sqlite.AddPrimaryKey(columns...)
+}
+
+func (sqliteBuilder) Index(name, table string, columns []string) Index {
+	return sqlite.AddIndex(name, table, columns...)
+}
+
+func (sqliteBuilder) UniqueIndex(name, table string, columns []string) Index {
+	return sqlite.AddUniqueIndex(name, table, columns...)
+}
+
+func (sqliteBuilder) ForeignKey(name string, columns, refColumns []string, refTable, onDelete, onUpdate string) ForeignKey {
+	var options []sqlite.ForeignKeyOption
+	if onDelete != "" {
+		options = append(options, sqlite.WithDeleteForeignKeyOption(sqlite.ForeignKeyOptionType(onDelete)))
+	}
+	if onUpdate != "" {
+		options = append(options, sqlite.WithUpdateForeignKeyOption(sqlite.ForeignKeyOptionType(onUpdate)))
+	}
+	return sqlite.AddForeignKey(columns, refColumns, refTable, options...)
+}
+
+func (sqliteBuilder) Check(name, expression string) Check {
+	return sqlite.AddCheck(name, expression)
+}
+
+type postgresBuilder struct{}
+
+func (postgresBuilder) PrimaryKey(columns []string) PrimaryKey {
+	return postgres.AddPrimaryKey(columns...)
+}
+
+func (postgresBuilder) Index(name, table string, columns []string) Index {
+	return postgres.AddIndex(name, table, columns...)
+}
+
+func (postgresBuilder) UniqueIndex(name, table string, columns []string) Index {
+	return postgres.AddUniqueIndex(name, table, columns...)
+}
+
+func (postgresBuilder) ForeignKey(name string, columns, refColumns []string, refTable, onDelete, onUpdate string) ForeignKey {
+	var options []postgres.ForeignKeyOption
+	if onDelete != "" {
+		options = append(options, postgres.WithDeleteForeignKeyOption(postgres.ForeignKeyOptionType(onDelete)))
+	}
+	if onUpdate != "" {
+		options = append(options, postgres.WithUpdateForeignKeyOption(postgres.ForeignKeyOptionType(onUpdate)))
+	}
+	return postgres.AddForeignKey(columns, refColumns, refTable, options...)
+}
+
+func (postgresBuilder) Check(name, expression string) Check {
+	return postgres.AddCheck(name, expression)
+}
+
+type sqlserverBuilder struct{}
+
+func (sqlserverBuilder) PrimaryKey(columns []string) PrimaryKey {
+	return sqlserver.AddPrimaryKey(columns...)
+}
+
+func (sqlserverBuilder) Index(name, table string, columns []string) Index {
+	return sqlserver.AddIndex(name, table, columns...)
+}
+
+func (sqlserverBuilder) UniqueIndex(name, table string, columns []string) Index {
+	return sqlserver.AddUniqueIndex(name, table, columns...)
+}
+
+func (sqlserverBuilder) ForeignKey(name string, columns, refColumns []string, refTable, onDelete, onUpdate string) ForeignKey {
+	var options []sqlserver.ForeignKeyOption
+	if onDelete != "" {
+		options = append(options, sqlserver.WithDeleteForeignKeyOption(sqlserver.ForeignKeyOptionType(onDelete)))
+	}
+	if onUpdate != "" {
+		options = append(options, sqlserver.WithUpdateForeignKeyOption(sqlserver.ForeignKeyOptionType(onUpdate)))
+	}
+	return sqlserver.AddForeignKey(columns, refColumns, refTable, options...)
+}
+
+func (sqlserverBuilder) Check(name, expression string) Check {
+	return sqlserver.AddCheck(name, expression)
+}
@@ -5,7 +5,10 @@ import (
 	"sort"
 
 	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
 	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlserver"
 )
 
 // Dialect is interface that eliminates differences in DB drivers.
@@ -13,9 +16,27 @@ type Dialect interface {
 	HeaderTemplate() string
 	FooterTemplate() string
 	TableTemplate() string
-	ToSQL(typeName string, size uint64) (string, error)
+	ToSQL(typeName string, t spec.Type) (string, error)
 	Quote(string) string
 	AutoIncrement() string
+	AttributesSQL(a spec.Attributes) (string, error)
+}
+
+// Migrator is implemented by dialects that can generate statements to alter
+// existing tables. Each method returns empty string if the change is not
+// supported by ALTER TABLE, in which case the table has to be rebuilt.
+type Migrator interface {
+	AddColumnSQL(table, column string, c spec.Column) string
+	DropColumnSQL(table, column string) string
+	ModifyColumnSQL(table, column string) string
+	AddIndexSQL(table, index string) string
+	DropIndexSQL(table, index string) string
+	AddForeignKeySQL(table, foreignKey string) string
+	DropForeignKeySQL(table, foreignKey string) string
+	AddPrimaryKeySQL(table, primaryKey string) string
+	DropPrimaryKeySQL(table string) string
+	DropTableSQL(table string) string
+	RenameTableSQL(from, to string) string
 }
 
 // Table is interface to generate tables for each DB (e.g. MySQL, PostgreSQL)
@@ -24,16 +45,41 @@ type Table interface {
 	PrimaryKey() PrimaryKey
 	ForeignKeys() ForeignKeys
 	Indexes() Indexes
+	Checks() Checks
+	Options() TableOptions
 	Columns() []Column
 	Dialect() Dialect
 }
 
+// TableOptions is options of the table (e.g. comment, engine, charset)
+type TableOptions = spec.TableOptions
+
+// Collator is implemented by dialects that declare charset and collation of columns.
+// CollationSQL returns the attributes of the column, or error if they are invalid.
+type Collator interface {
+	CollationSQL(charset, collation string) (string, error)
+}
+
+// Generator is implemented by dialects that declare generated columns.
+// GeneratedSQL returns the definition of the column following the column name, or error
+// if the column can not be generated (e.g. VIRTUAL of PostgreSQL).
+type Generator interface {
+	GeneratedSQL(columnType, expression string, stored bool, a spec.Attributes) (string, error)
+}
+
 // Column XXX
 type Column interface {
 	Name() string
+	Comment() string
 	ToSQL() (string, error)
 }
 
+// ColumnSpec is implemented by columns that know their parsed definition.
+// Migrators treat columns without it as the ones that can not be added by ALTER TABLE.
+type ColumnSpec interface {
+	Spec() (spec.Column, error)
+}
+
 // PrimaryKey is a model for determining the primary key
 type PrimaryKey interface {
 	Columns() []string
@@ -53,6 +99,13 @@ type ForeignKey interface {
 	ToSQL() string
 }
 
+// NamedForeignKey is implemented by foreign keys that are declared with constraint names (e.g. MySQL).
+// Name returns empty string if the name is not set.
+type NamedForeignKey interface {
+	ForeignKey
+	Name() string
+}
+
 // Sort is sort foreignKeys value by alphabets
 func (foreignKeys ForeignKeys) Sort() ForeignKeys {
 	fkMap := make(map[string]ForeignKey, 0)
@@ -101,6 +154,16 @@ func (indexes Indexes) Sort() Indexes {
 	return sortIndexes
 }
 
+// Checks is a slice of Check
+type Checks []Check
+
+// Check is model representing CHECK constraint of the table.
+type Check interface {
+	Name() string
+	Expression() string
+	ToSQL() string
+}
+
 // New creates a Dialect and returns it.
 func New(driver, engine, charset string) (Dialect, error) {
 	var d Dialect
@@ -113,6 +176,10 @@ func New(driver, engine, charset string) (Dialect, error) {
 		}
 	case "sqlite":
 		d = &sqlite.SQLite{}
+	case "postgres":
+		d = &postgres.PostgreSQL{}
+	case "sqlserver":
+		d = &sqlserver.SQLServer{}
 	default:
 		return d, fmt.Errorf("No such driver: %s", driver)
 	}
@@ -4,8 +4,11 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/nao1215/ddl-maker/dialect/mysql"
-	"github.com/nao1215/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlserver"
 )
 
 func TestSort(t *testing.T) {
@@ -121,6 +124,26 @@ func TestNew(t *testing.T) {
 			want:    &sqlite.SQLite{},
 			wantErr: false,
 		},
+		{
+			name: "[Normal] return postgres dialect",
+			args: args{
+				driver:  "postgres",
+				engine:  "",
+				charset: "",
+			},
+			want:    &postgres.PostgreSQL{},
+			wantErr: false,
+		},
+		{
+			name: "[Normal] return sqlserver dialect",
+			args: args{
+				driver:  "sqlserver",
+				engine:  "",
+				charset: "",
+			},
+			want:    &sqlserver.SQLServer{},
+			wantErr: false,
+		},
 		{
 			name: "[Error] no such driver",
 			args: args{
@@ -145,3 +168,50 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestTypes(t *testing.T) {
+	types := make(Types)
+	types.Register("github.com/google/uuid.UUID", Fixed("CHAR(36)"))
+	types.Register("example.Code", Sized("VARCHAR(%d)", 32))
+
+	tests := []struct {
+		name   string
+		goType string
+		size   uint64
+		want   string
+	}{
+		{name: "[Normal] fixed type", goType: "github.com/google/uuid.UUID", size: 10, want: "CHAR(36)"},
+		{name: "[Normal] default size", goType: "example.Code", want: "VARCHAR(32)"},
+		{name: "[Normal] size", goType: "example.Code", size: 8, want: "VARCHAR(8)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, ok := types.Lookup(tt.goType)
+			if !ok {
+				t.Fatalf("%s is not registered", tt.goType)
+			}
+			if got := fn(spec.Type{Size: tt.size}); got != tt.want {
+				t.Errorf("mismatch want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("[Normal] registered to the driver", func(t *testing.T) {
+		RegisterType("postgres", "example.Point", Fixed("POINT"))
+		t.Cleanup(func() { UnregisterType("postgres", "example.Point") })
+		if _, ok := LookupType(&postgres.PostgreSQL{}, "example.Point"); !ok {
+			t.Error("example.Point is not registered to postgres")
+		}
+		if _, ok := LookupType(mysql.MySQL{}, "example.Point"); ok {
+			t.Error("example.Point is registered to mysql")
+		}
+	})
+
+	t.Run("[Normal] unregistered from the driver", func(t *testing.T) {
+		RegisterType("postgres", "example.Line", Fixed("LINE"))
+		UnregisterType("postgres", "example.Line")
+		if _, ok := LookupType(postgres.PostgreSQL{}, "example.Line"); ok {
+			t.Error("example.Line is still registered to postgres")
+		}
+	})
+}
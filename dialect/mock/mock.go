@@ -1,5 +1,7 @@
 package mock
 
+import "github.com/mnhkahn/ddl-maker/dialect/spec"
+
 // SQLMock is struct for test
 type SQLMock struct {
 	Engine             string
@@ -25,7 +27,7 @@ func (mockSQL SQLMock) TableTemplate() string {
 }
 
 // ToSQL convert mockSQL sql string from typeName and size
-func (mockSQL SQLMock) ToSQL(typeName string, size uint64) (string, error) {
+func (mockSQL SQLMock) ToSQL(typeName string, t spec.Type) (string, error) {
 	return "", nil
 }
 
@@ -38,3 +40,8 @@ func (mockSQL SQLMock) Quote(s string) string {
 func (mockSQL SQLMock) AutoIncrement() string {
 	return ""
 }
+
+// AttributesSQL XXX
+func (mockSQL SQLMock) AttributesSQL(a spec.Attributes) (string, error) {
+	return "", nil
+}
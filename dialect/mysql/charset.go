@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCollation means the charset or collation is unknown, or the collation does not belong to the charset
+var ErrInvalidCollation = errors.New("Specified charset or collation is invalid")
+
+// collations maps charset into its collations.
+// https://dev.mysql.com/doc/refman/8.0/en/charset-charsets.html
+var collations = map[string][]string{
+	"armscii8": {"armscii8_general_ci", "armscii8_bin"},
+	"ascii":    {"ascii_general_ci", "ascii_bin"},
+	"big5":     {"big5_chinese_ci", "big5_bin"},
+	"binary":   {"binary"},
+	"cp1250":   {"cp1250_general_ci", "cp1250_bin", "cp1250_croatian_ci", "cp1250_czech_cs", "cp1250_polish_ci"},
+	"cp1251":   {"cp1251_general_ci", "cp1251_bin", "cp1251_bulgarian_ci", "cp1251_general_cs", "cp1251_ukrainian_ci"},
+	"cp1256":   {"cp1256_general_ci", "cp1256_bin"},
+	"cp1257":   {"cp1257_general_ci", "cp1257_bin", "cp1257_lithuanian_ci"},
+	"cp850":    {"cp850_general_ci", "cp850_bin"},
+	"cp852":    {"cp852_general_ci", "cp852_bin"},
+	"cp866":    {"cp866_general_ci", "cp866_bin"},
+	"cp932":    {"cp932_japanese_ci", "cp932_bin"},
+	"dec8":     {"dec8_swedish_ci", "dec8_bin"},
+	"eucjpms":  {"eucjpms_japanese_ci", "eucjpms_bin"},
+	"euckr":    {"euckr_korean_ci", "euckr_bin"},
+	"gb18030":  {"gb18030_chinese_ci", "gb18030_bin", "gb18030_unicode_520_ci"},
+	"gb2312":   {"gb2312_chinese_ci", "gb2312_bin"},
+	"gbk":      {"gbk_chinese_ci", "gbk_bin"},
+	"geostd8":  {"geostd8_general_ci", "geostd8_bin"},
+	"greek":    {"greek_general_ci", "greek_bin"},
+	"hebrew":   {"hebrew_general_ci", "hebrew_bin"},
+	"hp8":      {"hp8_english_ci", "hp8_bin"},
+	"keybcs2":  {"keybcs2_general_ci", "keybcs2_bin"},
+	"koi8r":    {"koi8r_general_ci", "koi8r_bin"},
+	"koi8u":    {"koi8u_general_ci", "koi8u_bin"},
+	"latin1": {
+		"latin1_swedish_ci", "latin1_bin", "latin1_danish_ci", "latin1_general_ci", "latin1_general_cs",
+		"latin1_german1_ci", "latin1_german2_ci", "latin1_spanish_ci",
+	},
+	"latin2":   {"latin2_general_ci", "latin2_bin", "latin2_croatian_ci", "latin2_czech_cs", "latin2_hungarian_ci"},
+	"latin5":   {"latin5_turkish_ci", "latin5_bin"},
+	"latin7":   {"latin7_general_ci", "latin7_bin", "latin7_estonian_cs", "latin7_general_cs"},
+	"macce":    {"macce_general_ci", "macce_bin"},
+	"macroman": {"macroman_general_ci", "macroman_bin"},
+	"sjis":     {"sjis_japanese_ci", "sjis_bin"},
+	"swe7":     {"swe7_swedish_ci", "swe7_bin"},
+	"tis620":   {"tis620_thai_ci", "tis620_bin"},
+	"ucs2":     {"ucs2_general_ci", "ucs2_bin", "ucs2_unicode_ci", "ucs2_unicode_520_ci", "ucs2_general_mysql500_ci"},
+	"ujis":     {"ujis_japanese_ci", "ujis_bin"},
+	"utf16":    {"utf16_general_ci", "utf16_bin", "utf16_unicode_ci", "utf16_unicode_520_ci"},
+	"utf16le":  {"utf16le_general_ci", "utf16le_bin"},
+	"utf32":    {"utf32_general_ci", "utf32_bin", "utf32_unicode_ci", "utf32_unicode_520_ci"},
+	"utf8": {
+		"utf8_general_ci", "utf8_bin", "utf8_unicode_ci", "utf8_unicode_520_ci", "utf8_general_mysql500_ci",
+	},
+	"utf8mb3": {
+		"utf8mb3_general_ci", "utf8mb3_bin", "utf8mb3_unicode_ci", "utf8mb3_unicode_520_ci", "utf8mb3_general_mysql500_ci",
+	},
+	"utf8mb4": {
+		"utf8mb4_general_ci", "utf8mb4_bin", "utf8mb4_unicode_ci", "utf8mb4_unicode_520_ci",
+		"utf8mb4_0900_ai_ci", "utf8mb4_0900_as_ci", "utf8mb4_0900_as_cs", "utf8mb4_0900_bin",
+		"utf8mb4_de_pb_0900_ai_ci", "utf8mb4_es_0900_ai_ci", "utf8mb4_ja_0900_as_cs", "utf8mb4_ja_0900_as_cs_ks",
+		"utf8mb4_ru_0900_ai_ci", "utf8mb4_zh_0900_as_cs",
+	},
+}
+
+// canonicalCharset returns the charset name that utf8 is an alias of.
+func canonicalCharset(charset string) string {
+	if charset == "utf8" {
+		return "utf8mb3"
+	}
+	return charset
+}
+
+// charsetOf returns the charset that the collation belongs to.
+func charsetOf(collation string) (string, bool) {
+	for charset, names := range collations {
+		for _, name := range names {
+			if name == collation {
+				return charset, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CollationSQL return CHARACTER SET and COLLATE attributes of the column.
+// It returns error if the charset or the collation is unknown, or the collation does not belong to the charset.
+func (mysql MySQL) CollationSQL(charset, collation string) (string, error) {
+	charset, collation = strings.ToLower(charset), strings.ToLower(collation)
+
+	var attributes []string
+	if charset != "" {
+		if _, ok := collations[charset]; !ok {
+			return "", fmt.Errorf("%w: unknown charset %s", ErrInvalidCollation, charset)
+		}
+		attributes = append(attributes, "CHARACTER SET "+charset)
+	}
+	if collation != "" {
+		owner, ok := charsetOf(collation)
+		if !ok {
+			return "", fmt.Errorf("%w: unknown collation %s", ErrInvalidCollation, collation)
+		}
+		if charset != "" && canonicalCharset(owner) != canonicalCharset(charset) {
+			return "", fmt.Errorf("%w: collation %s does not belong to charset %s", ErrInvalidCollation, collation, charset)
+		}
+		attributes = append(attributes, "COLLATE "+collation)
+	}
+	return strings.Join(attributes, " "), nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
 	"github.com/mnhkahn/ddl-maker/query"
 )
 
@@ -77,6 +78,7 @@ func (fkopt ForeignKeyOptionType) String() string {
 
 // ForeignKey is a model for setting foreign key constraints
 type ForeignKey struct {
+	name               string
 	foreignColumns     []string
 	referenceTableName string
 	referenceColumns   []string
@@ -123,6 +125,25 @@ func WithDeleteForeignKeyOption(option ForeignKeyOptionType) ForeignKeyOption {
 	return withDeleteForeignKeyOption(option)
 }
 
+type withNameForeignKeyOption string
+
+// Apply apply the constraint name of the foreign key.
+func (o withNameForeignKeyOption) Apply(f *ForeignKey) {
+	f.name = string(o)
+}
+
+// WithNameForeignKeyOption return the option that names the foreign key constraint.
+// Foreign keys without name can not be dropped by migration, because MySQL drops them by name.
+func WithNameForeignKeyOption(name string) ForeignKeyOption {
+	return withNameForeignKeyOption(name)
+}
+
+// ForeignKeyName returns the conventional constraint name of the foreign key of the table (fk_<table>_<columns>),
+// which can be set by WithNameForeignKeyOption.
+func ForeignKeyName(table string, foreignColumns []string) string {
+	return fmt.Sprintf("fk_%s_%s", strings.Trim(table, "`"), strings.Join(foreignColumns, "_"))
+}
+
 // HeaderTemplate return string that is sql header template
 func (mysql MySQL) HeaderTemplate() string {
 	return `SET foreign_key_checks=0;
@@ -150,14 +171,54 @@ CREATE TABLE {{ .Name }} (
     {{ range .ForeignKeys.Sort  -}}
         {{ .ToSQL }},
     {{ end -}}
+    {{ range .Checks -}}
+        {{ .ToSQL }},
+    {{ end -}}
     {{ .PrimaryKey.ToSQL }}
-) ENGINE={{ .Dialect.Engine }} DEFAULT CHARACTER SET {{ .Dialect.Charset }} COMMENT='comments';
+){{ with .Dialect.TableOptionsSQL .Options }} {{ . }}{{ end }};
 
 `
 }
 
+// TableOptionsSQL return table options of CREATE TABLE statement.
+// Engine and charset of the dialect are used unless they are specified by options.
+func (mysql MySQL) TableOptionsSQL(options spec.TableOptions) string {
+	engine, charset := options.Engine, options.Charset
+	if engine == "" {
+		engine = mysql.Engine
+	}
+	if charset == "" {
+		charset = mysql.Charset
+	}
+
+	var sql []string
+	if engine != "" {
+		sql = append(sql, "ENGINE="+engine)
+	}
+	if charset != "" {
+		sql = append(sql, "DEFAULT CHARACTER SET "+charset)
+	}
+	if options.Collation != "" {
+		sql = append(sql, "COLLATE "+options.Collation)
+	}
+	if options.RowFormat != "" {
+		sql = append(sql, "ROW_FORMAT="+strings.ToUpper(options.RowFormat))
+	}
+	if options.AutoIncrement > 0 {
+		sql = append(sql, fmt.Sprintf("AUTO_INCREMENT=%d", options.AutoIncrement))
+	}
+	if options.KeyBlockSize > 0 {
+		sql = append(sql, fmt.Sprintf("KEY_BLOCK_SIZE=%d", options.KeyBlockSize))
+	}
+	if options.Comment != "" {
+		sql = append(sql, "COMMENT="+query.QuoteLiteral(options.Comment))
+	}
+	return strings.Join(sql, " ")
+}
+
 // ToSQL convert mysql sql string from typeName and size
-func (mysql MySQL) ToSQL(typeName string, size uint64) (string, error) {
+func (mysql MySQL) ToSQL(typeName string, t spec.Type) (string, error) {
+	size := t.Size
 	switch typeName {
 	case "int8", "*int8":
 		return "TINYINT", nil
@@ -209,6 +270,10 @@ func (mysql MySQL) ToSQL(typeName string, size uint64) (string, error) {
 		return datetime(size), nil
 	case "sql.NullTime": // from Go 1.13
 		return datetime(size), nil
+	case "decimal", "numeric":
+		return decimal(t)
+	case "enum", "set":
+		return enum(typeName, t)
 	case "date":
 		return "DATE", nil
 	case "json.RawMessage", "*json.RawMessage":
@@ -230,6 +295,39 @@ func (mysql MySQL) AutoIncrement() string {
 	return autoIncrement
 }
 
+// AttributesSQL return NULL, DEFAULT, ON UPDATE, AUTO_INCREMENT and COMMENT attributes of the column.
+func (mysql MySQL) AttributesSQL(a spec.Attributes) (string, error) {
+	sql := []string{"NOT NULL"}
+	if a.Null {
+		sql[0] = "NULL"
+	}
+	if a.Default != "" {
+		sql = append(sql, "DEFAULT "+a.Default)
+	}
+	if a.OnUpdate != "" {
+		sql = append(sql, "ON UPDATE "+a.OnUpdate)
+	}
+	if a.AutoIncrement {
+		sql = append(sql, autoIncrement)
+	}
+	sql = append(sql, "COMMENT "+query.QuoteLiteral(a.Comment))
+	return strings.Join(sql, " "), nil
+}
+
+// GeneratedSQL return the column type with GENERATED ALWAYS AS clause and the attributes.
+// The column is STORED if stored is true, otherwise VIRTUAL.
+func (mysql MySQL) GeneratedSQL(columnType, expression string, stored bool, a spec.Attributes) (string, error) {
+	attributes, err := mysql.AttributesSQL(a)
+	if err != nil {
+		return "", err
+	}
+	kind := "VIRTUAL"
+	if stored {
+		kind = "STORED"
+	}
+	return fmt.Sprintf("%s GENERATED ALWAYS AS (%s) %s %s", columnType, expression, kind, attributes), nil
+}
+
 // Name return index name
 func (i Index) Name() string {
 	return i.name
@@ -285,6 +383,11 @@ func (fi FullTextIndex) WithParser(s string) FullTextIndex {
 	return fi
 }
 
+// Parser return parser name of full text index
+func (fi FullTextIndex) Parser() string {
+	return fi.parser
+}
+
 // ToSQL return full text index sql string
 func (fi FullTextIndex) ToSQL() string {
 	var columnsStr []string
@@ -334,6 +437,11 @@ func (pk PrimaryKey) ToSQL() string {
 	return fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(columnsStr, ", "))
 }
 
+// Name return the constraint name of the foreign key. It is empty if the name is not set.
+func (fk ForeignKey) Name() string {
+	return fk.name
+}
+
 // ForeignColumns XXX
 func (fk ForeignKey) ForeignColumns() []string {
 	return fk.foreignColumns
@@ -372,6 +480,9 @@ func (fk ForeignKey) ToSQL() string {
 		strings.Join(foreignColumnsStr, ", "),
 		query.Quote(fk.referenceTableName),
 		strings.Join(referenceColumnsStr, ", "))
+	if fk.name != "" {
+		sql = fmt.Sprintf("CONSTRAINT %s %s", query.Quote(fk.name), sql)
+	}
 	if fk.deleteOption != "" {
 		sql = sql + fmt.Sprintf(" ON DELETE %s", fk.deleteOption)
 	}
@@ -421,6 +532,55 @@ func AddPrimaryKey(columns ...string) PrimaryKey {
 	}
 }
 
+// decimal returns DECIMAL type with precision and scale.
+// https://dev.mysql.com/doc/refman/8.0/en/fixed-point-types.html
+func decimal(t spec.Type) (string, error) {
+	switch {
+	case t.Precision == 0 && t.Scale == 0:
+		return "DECIMAL", nil
+	case t.Precision == 0 || t.Scale > t.Precision || t.Precision > 65 || t.Scale > 30:
+		return "", fmt.Errorf("%w: DECIMAL(%d,%d)", ErrInvalidType, t.Precision, t.Scale)
+	}
+	return fmt.Sprintf("DECIMAL(%d,%d)", t.Precision, t.Scale), nil
+}
+
+// enum returns ENUM or SET type with the values.
+func enum(typeName string, t spec.Type) (string, error) {
+	if len(t.Values) == 0 {
+		return "", fmt.Errorf("%w: %s without values", ErrInvalidType, typeName)
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(typeName), query.QuoteLiterals(t.Values)), nil
+}
+
+// Check is model that represents CHECK constraint
+type Check struct {
+	name       string
+	expression string
+}
+
+// AddCheck returns a new Check
+func AddCheck(name, expression string) Check {
+	return Check{
+		name:       name,
+		expression: expression,
+	}
+}
+
+// Name return constraint name
+func (c Check) Name() string {
+	return c.name
+}
+
+// Expression return the condition of the constraint
+func (c Check) Expression() string {
+	return c.expression
+}
+
+// ToSQL return check constraint sql string
+func (c Check) ToSQL() string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", query.Quote(c.name), c.expression)
+}
+
 // AddForeignKey returns a new ForeignKey
 func AddForeignKey(foreignColumns, referenceColumns []string, referenceTableName string, option ...ForeignKeyOption) ForeignKey {
 	foreignKey := ForeignKey{
@@ -461,3 +621,58 @@ func datetime(size uint64) string {
 
 	return fmt.Sprintf("DATETIME(%d)", size)
 }
+
+// AddColumnSQL return statement that adds column to the table
+func (mysql MySQL) AddColumnSQL(table, column string, c spec.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, column)
+}
+
+// DropColumnSQL return statement that drops column from the table
+func (mysql MySQL) DropColumnSQL(table, column string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, query.Quote(column))
+}
+
+// ModifyColumnSQL return statement that changes column definition
+func (mysql MySQL) ModifyColumnSQL(table, column string) string {
+	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", table, column)
+}
+
+// AddIndexSQL return statement that adds index to the table
+func (mysql MySQL) AddIndexSQL(table, index string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", table, index)
+}
+
+// DropIndexSQL return statement that drops index from the table
+func (mysql MySQL) DropIndexSQL(table, index string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", table, query.Quote(index))
+}
+
+// AddForeignKeySQL return statement that adds foreign key to the table
+func (mysql MySQL) AddForeignKeySQL(table, foreignKey string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", table, foreignKey)
+}
+
+// DropForeignKeySQL return statement that drops foreign key from the table by the constraint name
+func (mysql MySQL) DropForeignKeySQL(table, foreignKey string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s;", table, query.Quote(foreignKey))
+}
+
+// AddPrimaryKeySQL return statement that adds primary key to the table
+func (mysql MySQL) AddPrimaryKeySQL(table, primaryKey string) string {
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", table, primaryKey)
+}
+
+// DropPrimaryKeySQL return statement that drops primary key from the table
+func (mysql MySQL) DropPrimaryKeySQL(table string) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", table)
+}
+
+// DropTableSQL return statement that drops the table
+func (mysql MySQL) DropTableSQL(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", table)
+}
+
+// RenameTableSQL return statement that renames the table
+func (mysql MySQL) RenameTableSQL(from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", from, to)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
 )
 
 func TestToSQL(t *testing.T) {
@@ -70,7 +71,7 @@ func TestToSQL(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		got, err := m.ToSQL(tc.typeName, tc.size)
+		got, err := m.ToSQL(tc.typeName, spec.Type{Size: tc.size})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -92,8 +93,8 @@ func TestToSQL2(t *testing.T) {
 	}
 
 	for _, tc := range testcases {
-		_, got := m.ToSQL(tc.typeName, tc.size)
-		if !errors.As(got, &tc.output) {
+		_, got := m.ToSQL(tc.typeName, spec.Type{Size: tc.size})
+		if !errors.Is(got, tc.output) {
 			t.Errorf("mismatch want=%v, got=%v", tc.output, got)
 		}
 	}
@@ -204,6 +205,18 @@ func TestAddForeignKey(t *testing.T) {
 	if fk.ToSQL() != "FOREIGN KEY (`product_category`, `product_id`) REFERENCES `product` (`category`, `id`) ON UPDATE CASCADE" {
 		t.Fatal("[error] parse foreign key", fk.ToSQL())
 	}
+
+	fk = AddForeignKey(foreignColumns, referenceColumns, "product", WithNameForeignKeyOption(ForeignKeyName("`order`", foreignColumns)))
+	if fk.ToSQL() != "CONSTRAINT `fk_order_product_category_product_id` FOREIGN KEY (`product_category`, `product_id`) REFERENCES `product` (`category`, `id`)" {
+		t.Fatal("[error] parse foreign key", fk.ToSQL())
+	}
+}
+
+func TestMySQL_DropForeignKeySQL(t *testing.T) {
+	got := MySQL{}.DropForeignKeySQL("`order`", "fk_order_product_id")
+	if got != "ALTER TABLE `order` DROP FOREIGN KEY `fk_order_product_id`;" {
+		t.Fatal("[error] drop foreign key", got)
+	}
 }
 
 func Test_varbinary(t *testing.T) {
@@ -384,8 +397,11 @@ CREATE TABLE {{ .Name }} (
     {{ range .ForeignKeys.Sort  -}}
         {{ .ToSQL }},
     {{ end -}}
+    {{ range .Checks -}}
+        {{ .ToSQL }},
+    {{ end -}}
     {{ .PrimaryKey.ToSQL }}
-) ENGINE={{ .Dialect.Engine }} DEFAULT CHARACTER SET {{ .Dialect.Charset }};
+){{ with .Dialect.TableOptionsSQL .Options }} {{ . }}{{ end }};
 
 `,
 		},
@@ -913,3 +929,148 @@ func TestForeignKey_DeleteOption(t *testing.T) {
 		})
 	}
 }
+
+func TestToSQL_Decimal(t *testing.T) {
+	d := MySQL{}
+
+	testcases := []struct {
+		spec   spec.Type
+		output string
+	}{
+		{spec.Type{}, "DECIMAL"},
+		{spec.Type{Precision: 10, Scale: 2}, "DECIMAL(10,2)"},
+		{spec.Type{Precision: 10}, "DECIMAL(10,0)"},
+		{spec.Type{Precision: 2, Scale: 3}, ""},
+		{spec.Type{Scale: 2}, ""},
+		{spec.Type{Precision: 66}, ""},
+	}
+
+	for _, tc := range testcases {
+		for _, typeName := range []string{"decimal", "numeric"} {
+			got, err := d.ToSQL(typeName, tc.spec)
+			if tc.output == "" {
+				if !errors.Is(err, ErrInvalidType) {
+					t.Errorf("mismatch %+v want=%v, got=%v", tc.spec, ErrInvalidType, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.output {
+				t.Errorf("mismatch %+v want=%s, got=%s", tc.spec, tc.output, got)
+			}
+		}
+	}
+}
+
+func TestToSQL_Enum(t *testing.T) {
+	d := MySQL{}
+
+	testcases := []struct {
+		typeName string
+		spec     spec.Type
+		output   string
+	}{
+		{"enum", spec.Type{Values: []string{"active", "banned"}, Column: "status"}, "ENUM('active','banned')"},
+		{"set", spec.Type{Values: []string{"read", "write"}}, "SET('read','write')"},
+		{"enum", spec.Type{Values: []string{"it's"}}, "ENUM('it''s')"},
+		{"enum", spec.Type{Column: "status"}, ""},
+	}
+
+	for _, tc := range testcases {
+		got, err := d.ToSQL(tc.typeName, tc.spec)
+		if tc.output == "" {
+			if !errors.Is(err, ErrInvalidType) {
+				t.Errorf("mismatch %s %+v want=%v, got=%v", tc.typeName, tc.spec, ErrInvalidType, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.output {
+			t.Errorf("mismatch %s %+v want=%s, got=%s", tc.typeName, tc.spec, tc.output, got)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := AddCheck("price_check", "price >= 0")
+	if c.Name() != "price_check" || c.Expression() != "price >= 0" {
+		t.Errorf("mismatch name=%s, expression=%s", c.Name(), c.Expression())
+	}
+	want := "CONSTRAINT `price_check` CHECK (price >= 0)"
+	if got := c.ToSQL(); got != want {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+}
+
+func TestMySQL_TableOptionsSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		mysql   MySQL
+		options spec.TableOptions
+		want    string
+	}{
+		{
+			name:  "[Normal] options of the dialect",
+			mysql: MySQL{Engine: "InnoDB", Charset: "utf8mb4"},
+			want:  "ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4",
+		},
+		{
+			name:  "[Normal] options override the dialect",
+			mysql: MySQL{Engine: "InnoDB", Charset: "utf8mb4"},
+			options: spec.TableOptions{
+				Comment:       "user's profile",
+				Engine:        "MyISAM",
+				Charset:       "utf8",
+				Collation:     "utf8_bin",
+				RowFormat:     "compressed",
+				AutoIncrement: 1000,
+				KeyBlockSize:  8,
+			},
+			want: "ENGINE=MyISAM DEFAULT CHARACTER SET utf8 COLLATE utf8_bin ROW_FORMAT=COMPRESSED AUTO_INCREMENT=1000 KEY_BLOCK_SIZE=8 COMMENT='user''s profile'",
+		},
+		{
+			name: "[Normal] no options",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mysql.TableOptionsSQL(tt.options); got != tt.want {
+				t.Errorf("mismatch want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMySQL_CollationSQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		charset   string
+		collation string
+		want      string
+		wantErr   error
+	}{
+		{name: "[Normal] charset and collation", charset: "utf8mb4", collation: "utf8mb4_bin", want: "CHARACTER SET utf8mb4 COLLATE utf8mb4_bin"},
+		{name: "[Normal] charset", charset: "ASCII", want: "CHARACTER SET ascii"},
+		{name: "[Normal] collation", collation: "ascii_bin", want: "COLLATE ascii_bin"},
+		{name: "[Normal] alias of utf8mb3", charset: "utf8", collation: "utf8mb3_bin", want: "CHARACTER SET utf8 COLLATE utf8mb3_bin"},
+		{name: "[Error] collation of another charset", charset: "ascii", collation: "utf8mb4_bin", wantErr: ErrInvalidCollation},
+		{name: "[Error] unknown charset", charset: "utf9", wantErr: ErrInvalidCollation},
+		{name: "[Error] unknown collation", collation: "utf8mb4_nocase", wantErr: ErrInvalidCollation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MySQL{}.CollationSQL(tt.charset, tt.collation)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
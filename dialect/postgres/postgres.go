@@ -0,0 +1,474 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+	"github.com/mnhkahn/ddl-maker/query"
+)
+
+const (
+	autoIncrement = "GENERATED BY DEFAULT AS IDENTITY"
+)
+
+// ErrInvalidType means Invalid type specified when parsing
+var ErrInvalidType = errors.New("Specified type is invalid")
+
+// ErrUnsupportedAttribute means the column attribute is not supported by PostgreSQL
+var ErrUnsupportedAttribute = errors.New("Specified attribute is not supported")
+
+// PostgreSQL is a model for PostgreSQL
+type PostgreSQL struct{}
+
+// HeaderTemplate return string that is sql header template
+func (pg PostgreSQL) HeaderTemplate() string {
+	return `BEGIN;
+`
+}
+
+// FooterTemplate return string that is sql footer template
+func (pg PostgreSQL) FooterTemplate() string {
+	return `COMMIT;
+`
+}
+
+// TableTemplate return string that is sql table template.
+// PostgreSQL does not support inline comments, so column comments are
+// declared by COMMENT ON statements after the table is created.
+func (pg PostgreSQL) TableTemplate() string {
+	return `
+DROP TABLE IF EXISTS {{ .Name }} CASCADE;
+
+CREATE TABLE {{ .Name }} (
+    {{ range .Columns -}}
+        {{ .ToSQL }},
+    {{ end -}}
+    {{ range .ForeignKeys.Sort  -}}
+        {{ .ToSQL }},
+    {{ end -}}
+    {{ range .Checks -}}
+        {{ .ToSQL }},
+    {{ end -}}
+    {{ .PrimaryKey.ToSQL }}
+);
+
+{{ range .Indexes.Sort -}}
+    {{ .ToSQL }}
+{{ end -}}
+{{ with .Options.Comment -}}
+    COMMENT ON TABLE {{ $.Name }} IS {{ $.Dialect.QuoteLiteral . }};
+{{ end -}}
+{{ range .Columns -}}
+    COMMENT ON COLUMN {{ $.Name }}.{{ $.Dialect.Quote .Name }} IS {{ $.Dialect.QuoteLiteral .Comment }};
+{{ end -}}
+
+`
+}
+
+// ToSQL convert postgres sql string from typeName and size
+func (pg PostgreSQL) ToSQL(typeName string, t spec.Type) (string, error) {
+	size := t.Size
+	switch typeName {
+	case "int8", "*int8":
+		return "SMALLINT", nil
+	case "int16", "*int16", "sql.NullInt16":
+		return "SMALLINT", nil
+	case "int32", "*int32", "sql.NullInt32":
+		return "INTEGER", nil
+	case "int64", "*int64", "sql.NullInt64":
+		return "BIGINT", nil
+	case "uint8", "*uint8":
+		return "SMALLINT", nil
+	case "uint16", "*uint16":
+		return "INTEGER", nil
+	case "uint32", "*uint32":
+		return "BIGINT", nil
+	case "uint64", "*uint64":
+		// BIGINT can not hold values over the max of int64
+		if t.AutoIncrement {
+			return "", fmt.Errorf("%w: identity column must be integer, but %s is NUMERIC(20) (use int64)", ErrInvalidType, typeName)
+		}
+		return "NUMERIC(20)", nil
+	case "Number":
+		return "BIGINT", nil
+	case "float32", "*float32":
+		return "REAL", nil
+	case "float64", "*float64", "sql.NullFloat64":
+		return "DOUBLE PRECISION", nil
+	case "string", "*string", "sql.NullString":
+		return varchar(size), nil
+	case "[]uint8", "sql.RawBytes":
+		return "BYTEA", nil
+	case "bool", "*bool", "sql.NullBool":
+		return "BOOLEAN", nil
+	case "tinytext", "text", "mediumtext", "longtext":
+		return "TEXT", nil
+	case "tinyblob", "blob", "mediumblob", "longblob", "bytea":
+		return "BYTEA", nil
+	case "time":
+		return "TIME", nil
+	case "timestamp":
+		return timestamp("TIMESTAMP", size), nil
+	case "time.Time", "*time.Time", "sql.NullTime", "timestamptz":
+		return timestamp("TIMESTAMPTZ", size), nil
+	case "decimal", "numeric":
+		return decimal(t)
+	case "enum":
+		return enum(t)
+	case "set":
+		return "TEXT", nil
+	case "date":
+		return "DATE", nil
+	case "json":
+		return "JSON", nil
+	case "json.RawMessage", "*json.RawMessage", "jsonb":
+		return "JSONB", nil
+	case "uuid":
+		return "UUID", nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidType, typeName)
+	}
+}
+
+// Quote encloses the string with "".
+func (pg PostgreSQL) Quote(s string) string {
+	return query.DoubleQuote(s)
+}
+
+// QuoteLiteral encloses the string with single quotes for string literal.
+func (pg PostgreSQL) QuoteLiteral(s string) string {
+	return query.QuoteLiteral(s)
+}
+
+// AutoIncrement return string for auto-increment setting
+func (pg PostgreSQL) AutoIncrement() string {
+	return autoIncrement
+}
+
+// AttributesSQL return NULL, DEFAULT and auto-increment attributes of the column.
+// Comments are declared by COMMENT ON statements of TableTemplate.
+// ON UPDATE is ignored because PostgreSQL does not support it, so that structs can be shared with MySQL.
+func (pg PostgreSQL) AttributesSQL(a spec.Attributes) (string, error) {
+	sql := []string{"NOT NULL"}
+	if a.Null {
+		sql[0] = "NULL"
+	}
+	if a.Default != "" {
+		sql = append(sql, "DEFAULT "+a.Default)
+	}
+	if a.AutoIncrement {
+		sql = append(sql, autoIncrement)
+	}
+	return strings.Join(sql, " "), nil
+}
+
+// GeneratedSQL return the column type with GENERATED ALWAYS AS clause and the attributes.
+// It returns error unless stored is true, because virtual generated columns are not supported before PostgreSQL 18.
+func (pg PostgreSQL) GeneratedSQL(columnType, expression string, stored bool, a spec.Attributes) (string, error) {
+	if !stored {
+		return "", fmt.Errorf("%w: VIRTUAL generated column", ErrUnsupportedAttribute)
+	}
+	attributes, err := pg.AttributesSQL(a)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s GENERATED ALWAYS AS (%s) STORED %s", columnType, expression, attributes), nil
+}
+
+// PrimaryKey is a model for determining the primary key
+type PrimaryKey struct {
+	columns []string
+}
+
+// AddPrimaryKey return initialized PrimaryKey struct.
+func AddPrimaryKey(columns ...string) PrimaryKey {
+	return PrimaryKey{
+		columns: columns,
+	}
+}
+
+// Columns returns the columns that will be the primary keys.
+func (pk PrimaryKey) Columns() []string {
+	return pk.columns
+}
+
+// ToSQL return primary key sql string.
+func (pk PrimaryKey) ToSQL() string {
+	return fmt.Sprintf("PRIMARY KEY (%s)", quoteColumns(pk.columns))
+}
+
+// Index is model representing indexes to speed up DB searches
+type Index struct {
+	columns []string
+	table   string
+	name    string
+}
+
+// AddIndex returns a new Index
+func AddIndex(idxName, table string, columns ...string) Index {
+	return Index{
+		name:    idxName,
+		table:   table,
+		columns: columns,
+	}
+}
+
+// Name return index name
+func (i Index) Name() string {
+	return i.name
+}
+
+// Table return table name
+func (i Index) Table() string {
+	return i.table
+}
+
+// Columns return index columns
+func (i Index) Columns() []string {
+	return i.columns
+}
+
+// ToSQL return index sql string
+func (i Index) ToSQL() string {
+	return fmt.Sprintf("CREATE INDEX %s ON %s (%s);",
+		query.DoubleQuote(i.name), query.DoubleQuote(i.table), quoteColumns(i.columns))
+}
+
+// UniqueIndex is model that represents unique constraints
+type UniqueIndex struct {
+	columns []string
+	table   string
+	name    string
+}
+
+// AddUniqueIndex returns a new UniqueIndex
+func AddUniqueIndex(idxName, table string, columns ...string) UniqueIndex {
+	return UniqueIndex{
+		name:    idxName,
+		table:   table,
+		columns: columns,
+	}
+}
+
+// Name return unique index name
+func (ui UniqueIndex) Name() string {
+	return ui.name
+}
+
+// Table return table name
+func (ui UniqueIndex) Table() string {
+	return ui.table
+}
+
+// Columns return unique index columns
+func (ui UniqueIndex) Columns() []string {
+	return ui.columns
+}
+
+// ToSQL return unique index sql string
+func (ui UniqueIndex) ToSQL() string {
+	return fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s);",
+		query.DoubleQuote(ui.name), query.DoubleQuote(ui.table), quoteColumns(ui.columns))
+}
+
+// ForeignKey is a model for setting foreign key constraints
+type ForeignKey struct {
+	foreignColumns     []string
+	referenceTableName string
+	referenceColumns   []string
+	updateOption       string
+	deleteOption       string
+}
+
+// ForeignKeyOptionType is string that means foreign key otion
+// https://www.postgresql.org/docs/current/ddl-constraints.html#DDL-CONSTRAINTS-FK
+type ForeignKeyOptionType string
+
+// ForeignKeyOptionCascade CASCADE
+var ForeignKeyOptionCascade ForeignKeyOptionType = "CASCADE"
+
+// ForeignKeyOptionSetNull SET NULL
+var ForeignKeyOptionSetNull ForeignKeyOptionType = "SET NULL"
+
+// ForeignKeyOptionRestrict RESTRICT
+var ForeignKeyOptionRestrict ForeignKeyOptionType = "RESTRICT"
+
+// ForeignKeyOptionNoAction NO ACTION
+var ForeignKeyOptionNoAction ForeignKeyOptionType = "NO ACTION"
+
+// ForeignKeyOptionSetDefault SET DEFAULT
+var ForeignKeyOptionSetDefault ForeignKeyOptionType = "SET DEFAULT"
+
+// String Stringer for ForeignKeyOptionType
+func (fkopt ForeignKeyOptionType) String() string {
+	return string(fkopt)
+}
+
+// ForeignKeyOption is an interface for controlling foreign key constraint options.
+type ForeignKeyOption interface {
+	Apply(*ForeignKey)
+}
+
+type withUpdateForeignKeyOption string
+
+// Apply apply foreign key constraint options for Update.
+func (o withUpdateForeignKeyOption) Apply(f *ForeignKey) {
+	f.updateOption = string(o)
+}
+
+// WithUpdateForeignKeyOption return query that is the foreign key constraint options for Update.
+func WithUpdateForeignKeyOption(option ForeignKeyOptionType) ForeignKeyOption {
+	switch option {
+	// NO ACTION is the default behavior, so the clause can be omitted.
+	case ForeignKeyOptionNoAction:
+		return withUpdateForeignKeyOption("")
+	}
+	return withUpdateForeignKeyOption(option)
+}
+
+type withDeleteForeignKeyOption string
+
+// Apply apply foreign key constraint options for Delete.
+func (o withDeleteForeignKeyOption) Apply(f *ForeignKey) {
+	f.deleteOption = string(o)
+}
+
+// WithDeleteForeignKeyOption return query that is the foreign key constraint options for Delete.
+func WithDeleteForeignKeyOption(option ForeignKeyOptionType) ForeignKeyOption {
+	switch option {
+	// NO ACTION is the default behavior, so the clause can be omitted.
+	case ForeignKeyOptionNoAction:
+		return withDeleteForeignKeyOption("")
+	}
+	return withDeleteForeignKeyOption(option)
+}
+
+// Check is model that represents CHECK constraint
+type Check struct {
+	name       string
+	expression string
+}
+
+// AddCheck returns a new Check
+func AddCheck(name, expression string) Check {
+	return Check{
+		name:       name,
+		expression: expression,
+	}
+}
+
+// Name return constraint name
+func (c Check) Name() string {
+	return c.name
+}
+
+// Expression return the condition of the constraint
+func (c Check) Expression() string {
+	return c.expression
+}
+
+// ToSQL return check constraint sql string
+func (c Check) ToSQL() string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", query.DoubleQuote(c.name), c.expression)
+}
+
+// AddForeignKey returns a new ForeignKey
+func AddForeignKey(foreignColumns, referenceColumns []string, referenceTableName string, option ...ForeignKeyOption) ForeignKey {
+	foreignKey := ForeignKey{
+		foreignColumns:     foreignColumns,
+		referenceTableName: referenceTableName,
+		referenceColumns:   referenceColumns,
+	}
+
+	for _, o := range option {
+		if o != nil {
+			o.Apply(&foreignKey)
+		}
+	}
+	return foreignKey
+}
+
+// ForeignColumns return slice of foreign key columns
+func (fk ForeignKey) ForeignColumns() []string {
+	return fk.foreignColumns
+}
+
+// ReferenceTableName return reference table name
+func (fk ForeignKey) ReferenceTableName() string {
+	return fk.referenceTableName
+}
+
+// ReferenceColumns return slice of reference columns
+func (fk ForeignKey) ReferenceColumns() []string {
+	return fk.referenceColumns
+}
+
+// UpdateOption return foreign key constraint option string for update
+func (fk ForeignKey) UpdateOption() string {
+	return fk.updateOption
+}
+
+// DeleteOption return foreign key constraint option string for delete
+func (fk ForeignKey) DeleteOption() string {
+	return fk.deleteOption
+}
+
+// decimal returns NUMERIC type with precision and scale.
+func decimal(t spec.Type) (string, error) {
+	switch {
+	case t.Precision == 0 && t.Scale == 0:
+		return "NUMERIC", nil
+	case t.Precision == 0 || t.Scale > t.Precision || t.Precision > 1000:
+		return "", fmt.Errorf("%w: NUMERIC(%d,%d)", ErrInvalidType, t.Precision, t.Scale)
+	}
+	return fmt.Sprintf("NUMERIC(%d,%d)", t.Precision, t.Scale), nil
+}
+
+// ToSQL return foreign key sql string
+func (fk ForeignKey) ToSQL() string {
+	sql := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+		quoteColumns(fk.foreignColumns),
+		query.DoubleQuote(fk.referenceTableName),
+		quoteColumns(fk.referenceColumns))
+	if fk.deleteOption != "" {
+		sql = sql + fmt.Sprintf(" ON DELETE %s", fk.deleteOption)
+	}
+	if fk.updateOption != "" {
+		sql = sql + fmt.Sprintf(" ON UPDATE %s", fk.updateOption)
+	}
+	return sql
+}
+
+func quoteColumns(columns []string) string {
+	var columnsStr []string
+	for _, c := range columns {
+		columnsStr = append(columnsStr, query.DoubleQuote(c))
+	}
+	return strings.Join(columnsStr, ", ")
+}
+
+func varchar(size uint64) string {
+	if size == 0 {
+		return "TEXT"
+	}
+
+	return fmt.Sprintf("VARCHAR(%d)", size)
+}
+
+func timestamp(typeName string, size uint64) string {
+	if size == 0 {
+		return typeName
+	}
+
+	return fmt.Sprintf("%s(%d)", typeName, size)
+}
+
+// enum returns TEXT type with CHECK constraint that restricts the value to one of the values.
+func enum(t spec.Type) (string, error) {
+	if len(t.Values) == 0 || t.Column == "" {
+		return "", fmt.Errorf("%w: enum without values or column", ErrInvalidType)
+	}
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", "TEXT", query.DoubleQuote(t.Column), query.QuoteLiterals(t.Values)), nil
+}
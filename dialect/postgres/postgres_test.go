@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+)
+
+func TestPostgreSQL_ToSQL(t *testing.T) {
+	pg := PostgreSQL{}
+
+	testcases := []struct {
+		typeName string
+		size     uint64
+		output   string
+	}{
+		{"bool", 0, "BOOLEAN"},
+		{"*bool", 0, "BOOLEAN"},
+		{"sql.NullBool", 0, "BOOLEAN"},
+		{"int8", 0, "SMALLINT"},
+		{"int16", 0, "SMALLINT"},
+		{"int32", 0, "INTEGER"},
+		{"sql.NullInt32", 0, "INTEGER"},
+		{"int64", 0, "BIGINT"},
+		{"sql.NullInt64", 0, "BIGINT"},
+		{"uint8", 0, "SMALLINT"},
+		{"uint16", 0, "INTEGER"},
+		{"uint32", 0, "BIGINT"},
+		{"uint64", 0, "NUMERIC(20)"},
+		{"float32", 0, "REAL"},
+		{"float64", 0, "DOUBLE PRECISION"},
+		{"string", 0, "TEXT"},
+		{"string", 10, "VARCHAR(10)"},
+		{"sql.NullString", 0, "TEXT"},
+		{"[]uint8", 0, "BYTEA"},
+		{"blob", 0, "BYTEA"},
+		{"text", 0, "TEXT"},
+		{"time", 0, "TIME"},
+		{"time.Time", 0, "TIMESTAMPTZ"},
+		{"time.Time", 6, "TIMESTAMPTZ(6)"},
+		{"timestamp", 0, "TIMESTAMP"},
+		{"date", 0, "DATE"},
+		{"json", 0, "JSON"},
+		{"json.RawMessage", 0, "JSONB"},
+		{"uuid", 0, "UUID"},
+	}
+
+	for _, tc := range testcases {
+		got, err := pg.ToSQL(tc.typeName, spec.Type{Size: tc.size})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.output {
+			t.Fatalf("error %s to sql %s. but result %s", tc.typeName, tc.output, got)
+		}
+	}
+
+	if _, err := pg.ToSQL("noExistType", spec.Type{}); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("mismatch want=%v, got=%v", ErrInvalidType, err)
+	}
+	if _, err := pg.ToSQL("uint64", spec.Type{AutoIncrement: true}); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("mismatch want=%v, got=%v", ErrInvalidType, err)
+	}
+}
+
+func TestPostgreSQL_Quote(t *testing.T) {
+	pg := PostgreSQL{}
+	if got := pg.Quote("id"); got != `"id"` {
+		t.Errorf("PostgreSQL.Quote() = %v, want %v", got, `"id"`)
+	}
+	if got := pg.QuoteLiteral("player's id"); got != `'player''s id'` {
+		t.Errorf("PostgreSQL.QuoteLiteral() = %v, want %v", got, `'player''s id'`)
+	}
+}
+
+func TestPostgreSQL_AutoIncrement(t *testing.T) {
+	pg := PostgreSQL{}
+	if pg.AutoIncrement() != autoIncrement {
+		t.Fatalf("error auto increament: %s. result:%s", autoIncrement, pg.AutoIncrement())
+	}
+}
+
+func TestAddPrimaryKey(t *testing.T) {
+	pk := AddPrimaryKey("id", "created_at")
+	if pk.ToSQL() != `PRIMARY KEY ("id", "created_at")` {
+		t.Fatal("[error] parse primary key", pk.ToSQL())
+	}
+}
+
+func TestAddIndex(t *testing.T) {
+	index := AddIndex("player_entry_id_idx", "comment", "player_id", "entry_id")
+	if index.ToSQL() != `CREATE INDEX "player_entry_id_idx" ON "comment" ("player_id", "entry_id");` {
+		t.Fatal("[error] parse player_entry_id_idx", index.ToSQL())
+	}
+	if index.Name() != "player_entry_id_idx" || index.Table() != "comment" {
+		t.Fatal("[error] index name and table should not be quoted", index.Name(), index.Table())
+	}
+}
+
+func TestAddUniqueIndex(t *testing.T) {
+	uniqIndex := AddUniqueIndex("token_uniq_idx", "player", "token")
+	if uniqIndex.ToSQL() != `CREATE UNIQUE INDEX "token_uniq_idx" ON "player" ("token");` {
+		t.Fatal("[error] parse token_uniq_idx", uniqIndex.ToSQL())
+	}
+}
+
+func TestAddForeignKey(t *testing.T) {
+	fk := AddForeignKey([]string{"player_id"}, []string{"id"}, "player")
+	if fk.ToSQL() != `FOREIGN KEY ("player_id") REFERENCES "player" ("id")` {
+		t.Fatal("[error] parse foreign key", fk.ToSQL())
+	}
+
+	fk = AddForeignKey([]string{"player_id"}, []string{"id"}, "player",
+		WithDeleteForeignKeyOption(ForeignKeyOptionCascade),
+		WithUpdateForeignKeyOption(ForeignKeyOptionNoAction))
+	if fk.ToSQL() != `FOREIGN KEY ("player_id") REFERENCES "player" ("id") ON DELETE CASCADE` {
+		t.Fatal("[error] parse foreign key", fk.ToSQL())
+	}
+
+	fk = AddForeignKey([]string{"player_id"}, []string{"id"}, "player",
+		WithUpdateForeignKeyOption(ForeignKeyOptionRestrict))
+	if fk.ToSQL() != `FOREIGN KEY ("player_id") REFERENCES "player" ("id") ON UPDATE RESTRICT` {
+		t.Fatal("[error] parse foreign key", fk.ToSQL())
+	}
+}
+
+func TestToSQL_Decimal(t *testing.T) {
+	d := PostgreSQL{}
+
+	testcases := []struct {
+		spec   spec.Type
+		output string
+	}{
+		{spec.Type{}, "NUMERIC"},
+		{spec.Type{Precision: 10, Scale: 2}, "NUMERIC(10,2)"},
+		{spec.Type{Precision: 10}, "NUMERIC(10,0)"},
+		{spec.Type{Precision: 2, Scale: 3}, ""},
+		{spec.Type{Scale: 2}, ""},
+	}
+
+	for _, tc := range testcases {
+		for _, typeName := range []string{"decimal", "numeric"} {
+			got, err := d.ToSQL(typeName, tc.spec)
+			if tc.output == "" {
+				if !errors.Is(err, ErrInvalidType) {
+					t.Errorf("mismatch %+v want=%v, got=%v", tc.spec, ErrInvalidType, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.output {
+				t.Errorf("mismatch %+v want=%s, got=%s", tc.spec, tc.output, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package spec defines options of column types, column attributes and tables that are passed to dialects.
+package spec
+
+// Type is options of the column type specified by struct tags.
+// Zero value means the option is not specified.
+type Type struct {
+	// Size is specified by size tag (e.g. VARCHAR(size))
+	Size uint64
+	// Precision is specified by precision tag or type=decimal(precision,scale)
+	Precision uint64
+	// Scale is specified by scale tag or type=decimal(precision,scale)
+	Scale uint64
+	// Values are members of ENUM and SET specified by enum and set tags or EnumValues()
+	Values []string
+	// Column is the column name that constraints of the type refer to (e.g. CHECK of enum)
+	Column string
+	// AutoIncrement is true if the value of the column is generated by the database (auto tag)
+	AutoIncrement bool
+}
+
+// TableOptions is options of the table declared by TableOptions() of the struct.
+// Zero value means the option of the dialect (e.g. engine and charset of Config) is used.
+type TableOptions struct {
+	Comment   string
+	Engine    string
+	Charset   string
+	Collation string
+	RowFormat string
+	// AutoIncrement is the start value of AUTO_INCREMENT
+	AutoIncrement uint64
+	KeyBlockSize  uint64
+}
+
+// Attributes is attributes of the column specified by struct tags and the Go type.
+// Each dialect renders the ones it supports after the column type.
+type Attributes struct {
+	// Null is true if the column is nullable
+	Null bool
+	// Default is the default value expression. Empty means no default value.
+	Default string
+	// OnUpdate is the expression set on update (e.g. CURRENT_TIMESTAMP). Empty means none.
+	OnUpdate string
+	// AutoIncrement is true if the value of the column is generated by the sequence
+	AutoIncrement bool
+	// Comment is the comment of the column
+	Comment string
+}
+
+// Column is the parsed definition of the column passed to dialects that decide
+// how the column can be added to the existing table.
+type Column struct {
+	Attributes
+	// Generated is the expression of the generated column. Empty means the column is not generated.
+	Generated string
+	// Stored is true if the generated column is stored
+	Stored bool
+	// Unique is true if the column is declared with UNIQUE constraint
+	Unique bool
+	// PrimaryKey is true if the column is a part of the primary key
+	PrimaryKey bool
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
 	"github.com/mnhkahn/ddl-maker/query"
 )
 
 // ErrInvalidType means Invalid type specified when parsing
 var ErrInvalidType = errors.New("Specified type is invalid")
 
+// ErrInvalidCollation means the charset or collation is not supported by SQLite
+var ErrInvalidCollation = errors.New("Specified charset or collation is invalid")
+
 const (
 	autoIncrement = "PRIMARY KEY AUTOINCREMENT"
 )
@@ -42,6 +46,9 @@ CREATE TABLE {{ .Name }} (
     {{ range .ForeignKeys.Sort  -}}
         {{ .ToSQL }},
     {{ end -}}
+    {{ range .Checks -}}
+        {{ .ToSQL }},
+    {{ end -}}
     {{ .PrimaryKey.ToSQL }}
 );
 
@@ -53,7 +60,7 @@ CREATE TABLE {{ .Name }} (
 }
 
 // ToSQL convert sqlite sql string from typeName and size
-func (sqlite SQLite) ToSQL(typeName string, size uint64) (string, error) {
+func (sqlite SQLite) ToSQL(typeName string, t spec.Type) (string, error) {
 	switch typeName {
 	case "int8", "*int8":
 		return "INTEGER", nil
@@ -103,6 +110,12 @@ func (sqlite SQLite) ToSQL(typeName string, size uint64) (string, error) {
 		return "INTEGER", nil
 	case "sql.NullTime":
 		return "INTEGER", nil
+	case "decimal", "numeric":
+		return decimal(t)
+	case "enum":
+		return enum(t)
+	case "set":
+		return "TEXT", nil
 	case "date":
 		return "INTEGER", nil
 	case "json.RawMessage", "*json.RawMessage":
@@ -122,6 +135,37 @@ func (sqlite SQLite) AutoIncrement() string {
 	return autoIncrement
 }
 
+// AttributesSQL return NULL, DEFAULT and auto-increment attributes of the column.
+// Comments are omitted because SQLite does not support them.
+// ON UPDATE is ignored because SQLite does not support it, so that structs can be shared with MySQL.
+func (sqlite SQLite) AttributesSQL(a spec.Attributes) (string, error) {
+	sql := []string{"NOT NULL"}
+	if a.Null {
+		sql[0] = "NULL"
+	}
+	if a.Default != "" {
+		sql = append(sql, "DEFAULT "+a.Default)
+	}
+	if a.AutoIncrement {
+		sql = append(sql, autoIncrement)
+	}
+	return strings.Join(sql, " "), nil
+}
+
+// GeneratedSQL return the column type with GENERATED ALWAYS AS clause and the attributes.
+// The column is STORED if stored is true, otherwise VIRTUAL.
+func (sqlite SQLite) GeneratedSQL(columnType, expression string, stored bool, a spec.Attributes) (string, error) {
+	attributes, err := sqlite.AttributesSQL(a)
+	if err != nil {
+		return "", err
+	}
+	kind := "VIRTUAL"
+	if stored {
+		kind = "STORED"
+	}
+	return fmt.Sprintf("%s GENERATED ALWAYS AS (%s) %s %s", columnType, expression, kind, attributes), nil
+}
+
 // PrimaryKey is a model for determining the primary key
 type PrimaryKey struct {
 	columns []string
@@ -302,6 +346,35 @@ func WithDeleteForeignKeyOption(option ForeignKeyOptionType) ForeignKeyOption {
 	return withDeleteForeignKeyOption(option)
 }
 
+// Check is model that represents CHECK constraint
+type Check struct {
+	name       string
+	expression string
+}
+
+// AddCheck returns a new Check
+func AddCheck(name, expression string) Check {
+	return Check{
+		name:       name,
+		expression: expression,
+	}
+}
+
+// Name return constraint name
+func (c Check) Name() string {
+	return c.name
+}
+
+// Expression return the condition of the constraint
+func (c Check) Expression() string {
+	return c.expression
+}
+
+// ToSQL return check constraint sql string
+func (c Check) ToSQL() string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", query.Quote(c.name), c.expression)
+}
+
 // AddForeignKey returns a new ForeignKey
 func AddForeignKey(foreignColumns, referenceColumns []string, referenceTableName string, option ...ForeignKeyOption) ForeignKey {
 	foreignKey := ForeignKey{
@@ -365,3 +438,111 @@ func (fk ForeignKey) ToSQL() string {
 	}
 	return sql
 }
+
+// AddColumnSQL return statement that adds column to the table, or empty string if
+// ALTER TABLE of SQLite can not add the column: PRIMARY KEY or UNIQUE column, STORED
+// generated column, NOT NULL column without default value, or the column whose default
+// value is CURRENT_TIME, CURRENT_DATE, CURRENT_TIMESTAMP or an expression in parentheses.
+func (sqlite SQLite) AddColumnSQL(table, column string, c spec.Column) string {
+	if c.PrimaryKey || c.Unique || c.AutoIncrement || (c.Generated != "" && c.Stored) {
+		return ""
+	}
+	def := strings.TrimSpace(c.Default)
+	if c.Generated == "" && !c.Null && (def == "" || strings.EqualFold(def, "NULL")) {
+		return ""
+	}
+	switch strings.ToUpper(def) {
+	case "CURRENT_TIME", "CURRENT_DATE", "CURRENT_TIMESTAMP":
+		return ""
+	}
+	if strings.HasPrefix(def, "(") {
+		return ""
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table, column)
+}
+
+// DropColumnSQL return empty string. The table needs to be rebuilt.
+func (sqlite SQLite) DropColumnSQL(table, column string) string {
+	return ""
+}
+
+// ModifyColumnSQL return empty string. The table needs to be rebuilt.
+func (sqlite SQLite) ModifyColumnSQL(table, column string) string {
+	return ""
+}
+
+// AddIndexSQL return statement that adds index. Index sql is CREATE INDEX statement.
+func (sqlite SQLite) AddIndexSQL(table, index string) string {
+	return index
+}
+
+// DropIndexSQL return statement that drops index. Index name is already quoted.
+func (sqlite SQLite) DropIndexSQL(table, index string) string {
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;", index)
+}
+
+// AddForeignKeySQL return empty string. The table needs to be rebuilt.
+func (sqlite SQLite) AddForeignKeySQL(table, foreignKey string) string {
+	return ""
+}
+
+// DropForeignKeySQL return empty string. The table needs to be rebuilt.
+func (sqlite SQLite) DropForeignKeySQL(table, foreignKey string) string {
+	return ""
+}
+
+// AddPrimaryKeySQL return empty string. The table needs to be rebuilt.
+func (sqlite SQLite) AddPrimaryKeySQL(table, primaryKey string) string {
+	return ""
+}
+
+// DropPrimaryKeySQL return empty string. The table needs to be rebuilt.
+func (sqlite SQLite) DropPrimaryKeySQL(table string) string {
+	return ""
+}
+
+// DropTableSQL return statement that drops the table
+func (sqlite SQLite) DropTableSQL(table string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;", table)
+}
+
+// RenameTableSQL return statement that renames the table
+func (sqlite SQLite) RenameTableSQL(from, to string) string {
+	return fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", from, to)
+}
+
+// decimal returns NUMERIC type with precision and scale.
+// SQLite ignores precision and scale, and stores the value by NUMERIC affinity.
+func decimal(t spec.Type) (string, error) {
+	switch {
+	case t.Precision == 0 && t.Scale == 0:
+		return "NUMERIC", nil
+	case t.Precision == 0 || t.Scale > t.Precision:
+		return "", fmt.Errorf("%w: NUMERIC(%d,%d)", ErrInvalidType, t.Precision, t.Scale)
+	}
+	return fmt.Sprintf("NUMERIC(%d,%d)", t.Precision, t.Scale), nil
+}
+
+// enum returns TEXT type with CHECK constraint that restricts the value to one of the values.
+func enum(t spec.Type) (string, error) {
+	if len(t.Values) == 0 || t.Column == "" {
+		return "", fmt.Errorf("%w: enum without values or column", ErrInvalidType)
+	}
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", "TEXT", query.Quote(t.Column), query.QuoteLiterals(t.Values)), nil
+}
+
+// CollationSQL return COLLATE attribute of the column. SQLite does not have
+// charset per column, and the collation is one of the built-in collating functions.
+// https://www.sqlite.org/datatype3.html#collating_sequences
+func (sqlite SQLite) CollationSQL(charset, collation string) (string, error) {
+	if charset != "" {
+		return "", fmt.Errorf("%w: charset %s is not supported", ErrInvalidCollation, charset)
+	}
+	switch c := strings.ToUpper(collation); c {
+	case "":
+		return "", nil
+	case "BINARY", "NOCASE", "RTRIM":
+		return "COLLATE " + c, nil
+	}
+	return "", fmt.Errorf("%w: unknown collation %s", ErrInvalidCollation, collation)
+}
@@ -1,8 +1,11 @@
 package sqlite
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
 )
 
 func TestSQLite_HeaderTemplate(t *testing.T) {
@@ -70,6 +73,9 @@ CREATE TABLE {{ .Name }} (
     {{ range .ForeignKeys.Sort  -}}
         {{ .ToSQL }},
     {{ end -}}
+    {{ range .Checks -}}
+        {{ .ToSQL }},
+    {{ end -}}
     {{ .PrimaryKey.ToSQL }}
 );
 
@@ -520,7 +526,7 @@ func TestSQLite_ToSQL(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sqlite := SQLite{}
-			got, err := sqlite.ToSQL(tt.args.typeName, tt.args.size)
+			got, err := sqlite.ToSQL(tt.args.typeName, spec.Type{Size: tt.args.size})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SQLite.ToSQL() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1314,3 +1320,104 @@ func TestForeignKey_ToSQL(t *testing.T) {
 		})
 	}
 }
+
+func TestToSQL_Decimal(t *testing.T) {
+	d := SQLite{}
+
+	testcases := []struct {
+		spec   spec.Type
+		output string
+	}{
+		{spec.Type{}, "NUMERIC"},
+		{spec.Type{Precision: 10, Scale: 2}, "NUMERIC(10,2)"},
+		{spec.Type{Precision: 10}, "NUMERIC(10,0)"},
+		{spec.Type{Precision: 2, Scale: 3}, ""},
+		{spec.Type{Scale: 2}, ""},
+	}
+
+	for _, tc := range testcases {
+		for _, typeName := range []string{"decimal", "numeric"} {
+			got, err := d.ToSQL(typeName, tc.spec)
+			if tc.output == "" {
+				if !errors.Is(err, ErrInvalidType) {
+					t.Errorf("mismatch %+v want=%v, got=%v", tc.spec, ErrInvalidType, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.output {
+				t.Errorf("mismatch %+v want=%s, got=%s", tc.spec, tc.output, got)
+			}
+		}
+	}
+}
+
+func TestToSQL_Enum(t *testing.T) {
+	d := SQLite{}
+
+	testcases := []struct {
+		typeName string
+		spec     spec.Type
+		output   string
+	}{
+		{"enum", spec.Type{Values: []string{"active", "banned"}, Column: "status"}, "TEXT CHECK (`status` IN ('active','banned'))"},
+		{"set", spec.Type{Values: []string{"read", "write"}, Column: "perm"}, "TEXT"},
+		{"enum", spec.Type{Column: "status"}, ""},
+		{"enum", spec.Type{Values: []string{"active"}}, ""},
+	}
+
+	for _, tc := range testcases {
+		got, err := d.ToSQL(tc.typeName, tc.spec)
+		if tc.output == "" {
+			if !errors.Is(err, ErrInvalidType) {
+				t.Errorf("mismatch %s %+v want=%v, got=%v", tc.typeName, tc.spec, ErrInvalidType, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.output {
+			t.Errorf("mismatch %s %+v want=%s, got=%s", tc.typeName, tc.spec, tc.output, got)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := AddCheck("price_check", "price >= 0")
+	if c.Name() != "price_check" || c.Expression() != "price >= 0" {
+		t.Errorf("mismatch name=%s, expression=%s", c.Name(), c.Expression())
+	}
+	want := "CONSTRAINT `price_check` CHECK (price >= 0)"
+	if got := c.ToSQL(); got != want {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+}
+
+func TestSQLite_CollationSQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		charset   string
+		collation string
+		want      string
+		wantErr   error
+	}{
+		{name: "[Normal] NOCASE", collation: "nocase", want: "COLLATE NOCASE"},
+		{name: "[Normal] RTRIM", collation: "RTRIM", want: "COLLATE RTRIM"},
+		{name: "[Error] charset", charset: "utf8mb4", wantErr: ErrInvalidCollation},
+		{name: "[Error] unknown collation", collation: "utf8mb4_bin", wantErr: ErrInvalidCollation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SQLite{}.CollationSQL(tt.charset, tt.collation)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mismatch want=%v, got=%v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("mismatch want=%s, got=%s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,468 @@
+package sqlserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+	"github.com/mnhkahn/ddl-maker/query"
+)
+
+const (
+	defaultNVarcharSize = 255
+	autoIncrement       = "IDENTITY(1,1)"
+)
+
+// ErrInvalidType means Invalid type specified when parsing
+var ErrInvalidType = errors.New("Specified type is invalid")
+
+// SQLServer is a model for Microsoft SQL Server (T-SQL)
+type SQLServer struct{}
+
+// HeaderTemplate return string that is sql header template
+func (ss SQLServer) HeaderTemplate() string {
+	return `SET ANSI_NULLS ON;
+SET QUOTED_IDENTIFIER ON;
+GO
+`
+}
+
+// FooterTemplate return string that is sql footer template
+func (ss SQLServer) FooterTemplate() string {
+	return ``
+}
+
+// TableTemplate return string that is sql table template.
+// Each statement is followed by GO batch separator for sqlcmd and SSMS.
+func (ss SQLServer) TableTemplate() string {
+	return `
+IF OBJECT_ID(N'{{ .Name }}', N'U') IS NOT NULL DROP TABLE {{ .Name }};
+GO
+
+CREATE TABLE {{ .Name }} (
+    {{ range .Columns -}}
+        {{ .ToSQL }},
+    {{ end -}}
+    {{ range .ForeignKeys.Sort  -}}
+        {{ .ToSQL }},
+    {{ end -}}
+    {{ range .Checks -}}
+        {{ .ToSQL }},
+    {{ end -}}
+    {{ .PrimaryKey.ToSQL }}
+);
+GO
+
+{{ range .Indexes.Sort -}}
+    {{ .ToSQL }}
+GO
+{{ end -}}
+
+`
+}
+
+// ToSQL convert sql server sql string from typeName and size
+func (ss SQLServer) ToSQL(typeName string, t spec.Type) (string, error) {
+	size := t.Size
+	switch typeName {
+	case "int8", "*int8":
+		return "SMALLINT", nil
+	case "int16", "*int16", "sql.NullInt16":
+		return "SMALLINT", nil
+	case "int32", "*int32", "sql.NullInt32":
+		return "INT", nil
+	case "int64", "*int64", "sql.NullInt64":
+		return "BIGINT", nil
+	case "uint8", "*uint8", "sql.NullByte":
+		return "TINYINT", nil
+	case "uint16", "*uint16":
+		return "INT", nil
+	case "uint32", "*uint32":
+		return "BIGINT", nil
+	case "uint64", "*uint64":
+		// BIGINT can not hold values over the max of int64
+		return "DECIMAL(20,0)", nil
+	case "Number":
+		return "BIGINT", nil
+	case "float32", "*float32":
+		return "REAL", nil
+	case "float64", "*float64", "sql.NullFloat64":
+		return "FLOAT", nil
+	case "string", "*string", "sql.NullString":
+		return nvarchar(size), nil
+	case "[]uint8", "sql.RawBytes":
+		return varbinary(size), nil
+	case "bool", "*bool", "sql.NullBool":
+		return "BIT", nil
+	case "tinytext", "text", "mediumtext", "longtext":
+		return "NVARCHAR(MAX)", nil
+	case "tinyblob", "blob", "mediumblob", "longblob":
+		return "VARBINARY(MAX)", nil
+	case "time":
+		return "TIME", nil
+	case "time.Time", "*time.Time", "sql.NullTime":
+		return datetime2(size), nil
+	case "decimal", "numeric":
+		return decimal(t)
+	case "enum":
+		return enum(t)
+	case "set":
+		return nvarchar(t.Size), nil
+	case "date":
+		return "DATE", nil
+	case "json.RawMessage", "*json.RawMessage":
+		return "NVARCHAR(MAX)", nil
+	case "uuid":
+		return "UNIQUEIDENTIFIER", nil
+	case "geometry":
+		return "GEOMETRY", nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidType, typeName)
+	}
+}
+
+// Quote encloses the string with [].
+func (ss SQLServer) Quote(s string) string {
+	return query.Bracket(s)
+}
+
+// AutoIncrement return string for auto-increment setting
+func (ss SQLServer) AutoIncrement() string {
+	return autoIncrement
+}
+
+// AttributesSQL return NULL, DEFAULT and auto-increment attributes of the column.
+// Comments are omitted because SQL Server keeps them as extended properties.
+// ON UPDATE is ignored because SQL Server does not support it, so that structs can be shared with MySQL.
+func (ss SQLServer) AttributesSQL(a spec.Attributes) (string, error) {
+	sql := []string{"NOT NULL"}
+	if a.Null {
+		sql[0] = "NULL"
+	}
+	if a.Default != "" {
+		sql = append(sql, "DEFAULT "+a.Default)
+	}
+	if a.AutoIncrement {
+		sql = append(sql, autoIncrement)
+	}
+	return strings.Join(sql, " "), nil
+}
+
+// GeneratedSQL return AS clause of the computed column. The column type is omitted because SQL Server
+// derives it from the expression. The column is PERSISTED if stored is true, and only persisted
+// columns can be NOT NULL. https://learn.microsoft.com/en-us/sql/t-sql/statements/alter-table-computed-column-definition-transact-sql
+func (ss SQLServer) GeneratedSQL(columnType, expression string, stored bool, a spec.Attributes) (string, error) {
+	sql := fmt.Sprintf("AS (%s)", expression)
+	if stored {
+		sql += " PERSISTED"
+		if !a.Null {
+			sql += " NOT NULL"
+		}
+	}
+	return sql, nil
+}
+
+// PrimaryKey is a model for determining the primary key
+type PrimaryKey struct {
+	columns []string
+}
+
+// AddPrimaryKey return initialized PrimaryKey struct.
+func AddPrimaryKey(columns ...string) PrimaryKey {
+	return PrimaryKey{
+		columns: columns,
+	}
+}
+
+// Columns returns the columns that will be the primary keys.
+func (pk PrimaryKey) Columns() []string {
+	return pk.columns
+}
+
+// ToSQL return primary key sql string.
+func (pk PrimaryKey) ToSQL() string {
+	return fmt.Sprintf("PRIMARY KEY (%s)", quoteColumns(pk.columns))
+}
+
+// Index is model representing indexes to speed up DB searches
+type Index struct {
+	columns []string
+	table   string
+	name    string
+}
+
+// AddIndex returns a new Index
+func AddIndex(idxName, table string, columns ...string) Index {
+	return Index{
+		name:    idxName,
+		table:   table,
+		columns: columns,
+	}
+}
+
+// Name return index name
+func (i Index) Name() string {
+	return i.name
+}
+
+// Table return table name
+func (i Index) Table() string {
+	return i.table
+}
+
+// Columns return index columns
+func (i Index) Columns() []string {
+	return i.columns
+}
+
+// ToSQL return index sql string
+func (i Index) ToSQL() string {
+	return fmt.Sprintf("CREATE INDEX %s ON %s (%s);",
+		query.Bracket(i.name), query.Bracket(i.table), quoteColumns(i.columns))
+}
+
+// UniqueIndex is model that represents unique constraints
+type UniqueIndex struct {
+	columns []string
+	table   string
+	name    string
+}
+
+// AddUniqueIndex returns a new UniqueIndex
+func AddUniqueIndex(idxName, table string, columns ...string) UniqueIndex {
+	return UniqueIndex{
+		name:    idxName,
+		table:   table,
+		columns: columns,
+	}
+}
+
+// Name return unique index name
+func (ui UniqueIndex) Name() string {
+	return ui.name
+}
+
+// Table return table name
+func (ui UniqueIndex) Table() string {
+	return ui.table
+}
+
+// Columns return unique index columns
+func (ui UniqueIndex) Columns() []string {
+	return ui.columns
+}
+
+// ToSQL return unique index sql string
+func (ui UniqueIndex) ToSQL() string {
+	return fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s);",
+		query.Bracket(ui.name), query.Bracket(ui.table), quoteColumns(ui.columns))
+}
+
+// ForeignKey is a model for setting foreign key constraints
+type ForeignKey struct {
+	foreignColumns     []string
+	referenceTableName string
+	referenceColumns   []string
+	updateOption       string
+	deleteOption       string
+}
+
+// ForeignKeyOptionType is string that means foreign key otion
+// https://learn.microsoft.com/en-us/sql/relational-databases/tables/primary-and-foreign-key-constraints
+type ForeignKeyOptionType string
+
+// ForeignKeyOptionCascade CASCADE
+var ForeignKeyOptionCascade ForeignKeyOptionType = "CASCADE"
+
+// ForeignKeyOptionSetNull SET NULL
+var ForeignKeyOptionSetNull ForeignKeyOptionType = "SET NULL"
+
+// ForeignKeyOptionRestrict RESTRICT
+var ForeignKeyOptionRestrict ForeignKeyOptionType = "RESTRICT"
+
+// ForeignKeyOptionNoAction NO ACTION
+var ForeignKeyOptionNoAction ForeignKeyOptionType = "NO ACTION"
+
+// ForeignKeyOptionSetDefault SET DEFAULT
+var ForeignKeyOptionSetDefault ForeignKeyOptionType = "SET DEFAULT"
+
+// String Stringer for ForeignKeyOptionType
+func (fkopt ForeignKeyOptionType) String() string {
+	return string(fkopt)
+}
+
+// ForeignKeyOption is an interface for controlling foreign key constraint options.
+type ForeignKeyOption interface {
+	Apply(*ForeignKey)
+}
+
+type withUpdateForeignKeyOption string
+
+// Apply apply foreign key constraint options for Update.
+func (o withUpdateForeignKeyOption) Apply(f *ForeignKey) {
+	f.updateOption = string(o)
+}
+
+// WithUpdateForeignKeyOption return query that is the foreign key constraint options for Update.
+func WithUpdateForeignKeyOption(option ForeignKeyOptionType) ForeignKeyOption {
+	switch option {
+	// SQL Server does not support RESTRICT. NO ACTION is the default behavior and equivalent.
+	case ForeignKeyOptionRestrict, ForeignKeyOptionNoAction:
+		return withUpdateForeignKeyOption("")
+	}
+	return withUpdateForeignKeyOption(option)
+}
+
+type withDeleteForeignKeyOption string
+
+// Apply apply foreign key constraint options for Delete.
+func (o withDeleteForeignKeyOption) Apply(f *ForeignKey) {
+	f.deleteOption = string(o)
+}
+
+// WithDeleteForeignKeyOption return query that is the foreign key constraint options for Delete.
+func WithDeleteForeignKeyOption(option ForeignKeyOptionType) ForeignKeyOption {
+	switch option {
+	// SQL Server does not support RESTRICT. NO ACTION is the default behavior and equivalent.
+	case ForeignKeyOptionRestrict, ForeignKeyOptionNoAction:
+		return withDeleteForeignKeyOption("")
+	}
+	return withDeleteForeignKeyOption(option)
+}
+
+// Check is model that represents CHECK constraint
+type Check struct {
+	name       string
+	expression string
+}
+
+// AddCheck returns a new Check
+func AddCheck(name, expression string) Check {
+	return Check{
+		name:       name,
+		expression: expression,
+	}
+}
+
+// Name return constraint name
+func (c Check) Name() string {
+	return c.name
+}
+
+// Expression return the condition of the constraint
+func (c Check) Expression() string {
+	return c.expression
+}
+
+// ToSQL return check constraint sql string
+func (c Check) ToSQL() string {
+	return fmt.Sprintf("CONSTRAINT %s CHECK (%s)", query.Bracket(c.name), c.expression)
+}
+
+// AddForeignKey returns a new ForeignKey
+func AddForeignKey(foreignColumns, referenceColumns []string, referenceTableName string, option ...ForeignKeyOption) ForeignKey {
+	foreignKey := ForeignKey{
+		foreignColumns:     foreignColumns,
+		referenceTableName: referenceTableName,
+		referenceColumns:   referenceColumns,
+	}
+
+	for _, o := range option {
+		if o != nil {
+			o.Apply(&foreignKey)
+		}
+	}
+	return foreignKey
+}
+
+// ForeignColumns return slice of foreign key columns
+func (fk ForeignKey) ForeignColumns() []string {
+	return fk.foreignColumns
+}
+
+// ReferenceTableName return reference table name
+func (fk ForeignKey) ReferenceTableName() string {
+	return fk.referenceTableName
+}
+
+// ReferenceColumns return slice of reference columns
+func (fk ForeignKey) ReferenceColumns() []string {
+	return fk.referenceColumns
+}
+
+// UpdateOption return foreign key constraint option string for update
+func (fk ForeignKey) UpdateOption() string {
+	return fk.updateOption
+}
+
+// DeleteOption return foreign key constraint option string for delete
+func (fk ForeignKey) DeleteOption() string {
+	return fk.deleteOption
+}
+
+// decimal returns DECIMAL type with precision and scale.
+func decimal(t spec.Type) (string, error) {
+	switch {
+	case t.Precision == 0 && t.Scale == 0:
+		return "DECIMAL", nil
+	case t.Precision == 0 || t.Scale > t.Precision || t.Precision > 38:
+		return "", fmt.Errorf("%w: DECIMAL(%d,%d)", ErrInvalidType, t.Precision, t.Scale)
+	}
+	return fmt.Sprintf("DECIMAL(%d,%d)", t.Precision, t.Scale), nil
+}
+
+// ToSQL return foreign key sql string
+func (fk ForeignKey) ToSQL() string {
+	sql := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+		quoteColumns(fk.foreignColumns),
+		query.Bracket(fk.referenceTableName),
+		quoteColumns(fk.referenceColumns))
+	if fk.deleteOption != "" {
+		sql = sql + fmt.Sprintf(" ON DELETE %s", fk.deleteOption)
+	}
+	if fk.updateOption != "" {
+		sql = sql + fmt.Sprintf(" ON UPDATE %s", fk.updateOption)
+	}
+	return sql
+}
+
+func quoteColumns(columns []string) string {
+	var columnsStr []string
+	for _, c := range columns {
+		columnsStr = append(columnsStr, query.Bracket(c))
+	}
+	return strings.Join(columnsStr, ", ")
+}
+
+func nvarchar(size uint64) string {
+	if size == 0 {
+		return fmt.Sprintf("NVARCHAR(%d)", defaultNVarcharSize)
+	}
+
+	return fmt.Sprintf("NVARCHAR(%d)", size)
+}
+
+func varbinary(size uint64) string {
+	if size == 0 {
+		return "VARBINARY(MAX)"
+	}
+
+	return fmt.Sprintf("VARBINARY(%d)", size)
+}
+
+func datetime2(size uint64) string {
+	if size == 0 {
+		return "DATETIME2"
+	}
+
+	return fmt.Sprintf("DATETIME2(%d)", size)
+}
+
+// enum returns NVARCHAR type with CHECK constraint that restricts the value to one of the values.
+func enum(t spec.Type) (string, error) {
+	if len(t.Values) == 0 || t.Column == "" {
+		return "", fmt.Errorf("%w: enum without values or column", ErrInvalidType)
+	}
+	return fmt.Sprintf("%s CHECK (%s IN (%s))", nvarchar(t.Size), query.Bracket(t.Column), query.QuoteLiterals(t.Values)), nil
+}
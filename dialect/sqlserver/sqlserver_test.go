@@ -0,0 +1,136 @@
+package sqlserver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+)
+
+func TestSQLServer_ToSQL(t *testing.T) {
+	ss := SQLServer{}
+
+	testcases := []struct {
+		typeName string
+		size     uint64
+		output   string
+	}{
+		{"bool", 0, "BIT"},
+		{"sql.NullBool", 0, "BIT"},
+		{"int8", 0, "SMALLINT"},
+		{"int16", 0, "SMALLINT"},
+		{"int32", 0, "INT"},
+		{"int64", 0, "BIGINT"},
+		{"sql.NullInt64", 0, "BIGINT"},
+		{"uint8", 0, "TINYINT"},
+		{"uint16", 0, "INT"},
+		{"uint32", 0, "BIGINT"},
+		{"uint64", 0, "DECIMAL(20,0)"},
+		{"float32", 0, "REAL"},
+		{"float64", 0, "FLOAT"},
+		{"string", 0, "NVARCHAR(255)"},
+		{"string", 10, "NVARCHAR(10)"},
+		{"sql.NullString", 0, "NVARCHAR(255)"},
+		{"text", 0, "NVARCHAR(MAX)"},
+		{"[]uint8", 0, "VARBINARY(MAX)"},
+		{"[]uint8", 16, "VARBINARY(16)"},
+		{"blob", 0, "VARBINARY(MAX)"},
+		{"time", 0, "TIME"},
+		{"time.Time", 0, "DATETIME2"},
+		{"time.Time", 3, "DATETIME2(3)"},
+		{"date", 0, "DATE"},
+		{"uuid", 0, "UNIQUEIDENTIFIER"},
+	}
+
+	for _, tc := range testcases {
+		got, err := ss.ToSQL(tc.typeName, spec.Type{Size: tc.size})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.output {
+			t.Fatalf("error %s to sql %s. but result %s", tc.typeName, tc.output, got)
+		}
+	}
+
+	if _, err := ss.ToSQL("noExistType", spec.Type{}); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("mismatch want=%v, got=%v", ErrInvalidType, err)
+	}
+}
+
+func TestSQLServer_Quote(t *testing.T) {
+	ss := SQLServer{}
+	if got := ss.Quote("user"); got != "[user]" {
+		t.Errorf("SQLServer.Quote() = %v, want %v", got, "[user]")
+	}
+}
+
+func TestSQLServer_AutoIncrement(t *testing.T) {
+	ss := SQLServer{}
+	if ss.AutoIncrement() != autoIncrement {
+		t.Fatalf("error auto increament: %s. result:%s", autoIncrement, ss.AutoIncrement())
+	}
+}
+
+func TestAddPrimaryKey(t *testing.T) {
+	pk := AddPrimaryKey("id", "created_at")
+	if pk.ToSQL() != "PRIMARY KEY ([id], [created_at])" {
+		t.Fatal("[error] parse primary key", pk.ToSQL())
+	}
+}
+
+func TestAddIndex(t *testing.T) {
+	index := AddIndex("player_entry_id_idx", "comment", "player_id", "entry_id")
+	if index.ToSQL() != "CREATE INDEX [player_entry_id_idx] ON [comment] ([player_id], [entry_id]);" {
+		t.Fatal("[error] parse player_entry_id_idx", index.ToSQL())
+	}
+}
+
+func TestAddUniqueIndex(t *testing.T) {
+	uniqIndex := AddUniqueIndex("token_uniq_idx", "player", "token")
+	if uniqIndex.ToSQL() != "CREATE UNIQUE INDEX [token_uniq_idx] ON [player] ([token]);" {
+		t.Fatal("[error] parse token_uniq_idx", uniqIndex.ToSQL())
+	}
+}
+
+func TestAddForeignKey(t *testing.T) {
+	fk := AddForeignKey([]string{"player_id"}, []string{"id"}, "player",
+		WithDeleteForeignKeyOption(ForeignKeyOptionCascade),
+		WithUpdateForeignKeyOption(ForeignKeyOptionRestrict))
+	if fk.ToSQL() != "FOREIGN KEY ([player_id]) REFERENCES [player] ([id]) ON DELETE CASCADE" {
+		t.Fatal("[error] parse foreign key", fk.ToSQL())
+	}
+}
+
+func TestToSQL_Decimal(t *testing.T) {
+	d := SQLServer{}
+
+	testcases := []struct {
+		spec   spec.Type
+		output string
+	}{
+		{spec.Type{}, "DECIMAL"},
+		{spec.Type{Precision: 10, Scale: 2}, "DECIMAL(10,2)"},
+		{spec.Type{Precision: 10}, "DECIMAL(10,0)"},
+		{spec.Type{Precision: 2, Scale: 3}, ""},
+		{spec.Type{Scale: 2}, ""},
+		{spec.Type{Precision: 39}, ""},
+	}
+
+	for _, tc := range testcases {
+		for _, typeName := range []string{"decimal", "numeric"} {
+			got, err := d.ToSQL(typeName, tc.spec)
+			if tc.output == "" {
+				if !errors.Is(err, ErrInvalidType) {
+					t.Errorf("mismatch %+v want=%v, got=%v", tc.spec, ErrInvalidType, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.output {
+				t.Errorf("mismatch %+v want=%s, got=%s", tc.spec, tc.output, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package dialect
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlserver"
+)
+
+// TypeFunc returns SQL type from options specified by tags (e.g. size).
+type TypeFunc func(t spec.Type) string
+
+// Fixed returns TypeFunc that always returns sqlType.
+func Fixed(sqlType string) TypeFunc {
+	return func(spec.Type) string {
+		return sqlType
+	}
+}
+
+// Sized returns TypeFunc that formats the size by format (e.g. "VARBINARY(%d)").
+// defaultSize is used when size tag is not specified.
+func Sized(format string, defaultSize uint64) TypeFunc {
+	return func(t spec.Type) string {
+		size := t.Size
+		if size == 0 {
+			size = defaultSize
+		}
+		return fmt.Sprintf(format, size)
+	}
+}
+
+// Types maps fully qualified Go type (e.g. github.com/google/uuid.UUID) into SQL type.
+type Types map[string]TypeFunc
+
+// Register maps goType into SQL type returned by fn.
+func (t Types) Register(goType string, fn TypeFunc) {
+	t[goType] = fn
+}
+
+// Lookup returns TypeFunc of goType.
+func (t Types) Lookup(goType string) (TypeFunc, bool) {
+	fn, ok := t[goType]
+	return fn, ok
+}
+
+var (
+	driverTypesMu sync.RWMutex
+	driverTypes   = make(map[string]Types)
+)
+
+// RegisterType maps goType into SQL type for all tables of the driver (e.g. "mysql").
+// Types registered to DDLMaker take precedence over it.
+func RegisterType(driver, goType string, fn TypeFunc) {
+	driverTypesMu.Lock()
+	defer driverTypesMu.Unlock()
+
+	if driverTypes[driver] == nil {
+		driverTypes[driver] = make(Types)
+	}
+	driverTypes[driver].Register(goType, fn)
+}
+
+// UnregisterType removes goType registered by RegisterType for the driver.
+func UnregisterType(driver, goType string) {
+	driverTypesMu.Lock()
+	defer driverTypesMu.Unlock()
+
+	delete(driverTypes[driver], goType)
+}
+
+// LookupType returns TypeFunc of goType registered by RegisterType for the dialect.
+func LookupType(d Dialect, goType string) (TypeFunc, bool) {
+	driverTypesMu.RLock()
+	defer driverTypesMu.RUnlock()
+
+	return driverTypes[Driver(d)].Lookup(goType)
+}
+
+// Driver returns driver name of the dialect that is accepted by New.
+// It returns empty string for unknown dialects.
+func Driver(d Dialect) string {
+	switch d.(type) {
+	case mysql.MySQL, *mysql.MySQL:
+		return "mysql"
+	case sqlite.SQLite, *sqlite.SQLite:
+		return "sqlite"
+	case postgres.PostgreSQL, *postgres.PostgreSQL:
+		return "postgres"
+	case sqlserver.SQLServer, *sqlserver.SQLServer:
+		return "sqlserver"
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package ddlmaker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError is the error of the struct field that can not be converted into a column.
+type FieldError struct {
+	// Struct is the name of the struct added to DDLMaker
+	Struct string
+	// Path is the names of the embedded struct fields from Struct to the field (e.g. Base.Timestamps).
+	// It is empty for fields declared by Struct itself.
+	Path string
+	// Field is the name of the field
+	Field string
+	// Type is the Go type of the field (e.g. *time.Time)
+	Type string
+	// Tag is the value of ddl tag of the field
+	Tag string
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	field := e.Field
+	if e.Path != "" {
+		field = e.Path + "." + e.Field
+	}
+	return fmt.Sprintf("error struct %s field %s %s `%s:%q`: %v", e.Struct, field, e.Type, TAGPREFIX, e.Tag, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// TableError is the error of the struct that can not be converted into a table
+// (e.g. invalid primary key, duplicate columns).
+type TableError struct {
+	// Struct is the name of the struct
+	Struct string
+	// Table is the name of the table
+	Table string
+	Err   error
+}
+
+func (e *TableError) Error() string {
+	return fmt.Sprintf("error struct %s (table %s): %v", e.Struct, e.Table, e.Err)
+}
+
+func (e *TableError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError is the errors of all structs and fields that can not be parsed.
+// Each error is *FieldError or *TableError, and errors.Is and errors.As find them.
+type ParseError struct {
+	Errors []error
+}
+
+func (e *ParseError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is reports whether any of the errors matches target.
+func (e *ParseError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error that matches target.
+func (e *ParseError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// LintError is the error of diagnostics of SeverityError found by Lint, which stop the generation.
+type LintError struct {
+	Diagnostics []Diagnostic
+}
+
+func (e *LintError) Error() string {
+	msgs := make([]string, 0, len(e.Diagnostics))
+	for _, d := range e.Diagnostics {
+		msgs = append(msgs, d.String())
+	}
+	return strings.Join(msgs, "\n")
+}
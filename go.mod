@@ -3,6 +3,7 @@ module github.com/mnhkahn/ddl-maker
 go 1.18
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/bournex/ordered_container v0.0.0-20230727052507-5f85dae3d09f
 	github.com/google/go-cmp v0.5.8
 	github.com/mnhkahn/gogogo v1.0.9
@@ -10,11 +11,11 @@ require (
 	github.com/nao1215/nameconv v1.0.1
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0-20170531160350-a96e63847dc3 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,248 @@
+package ddlmaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mnhkahn/ddl-maker/query"
+)
+
+// ManifestFileName is the name of the manifest written into the directory of LayoutTable and LayoutStatement.
+const ManifestFileName = "manifest.json"
+
+// foreignKeysFileName is the file of LayoutTable that has foreign keys of cycles added after all tables are created.
+const foreignKeysFileName = "_foreign_keys.sql"
+
+// Manifest lists the files written by GenerateFiles.
+type Manifest struct {
+	Layout string         `json:"layout"`
+	Files  []ManifestFile `json:"files"`
+}
+
+// ManifestFile is the file written by GenerateFiles.
+type ManifestFile struct {
+	// Path is OutFilePath for LayoutSingle, and the path relative to OutFilePath for the other layouts
+	Path string `json:"path"`
+	// Table is the name of the table written in the file. It is empty for header, footer and foreign keys of cycles.
+	Table string `json:"table,omitempty"`
+	// Statements is the number of the statements in the file
+	Statements int `json:"statements"`
+}
+
+// GenerateFiles writes DDL of added structs by Layout of the config, and returns the manifest of
+// written files. LayoutSingle writes into OutFilePath file. LayoutTable and LayoutStatement write
+// into OutFilePath directory with ManifestFileName. Structs are parsed unless they are parsed after the last AddStruct.
+// If any diagnostic of Lint is SeverityError, no file is written and *LintError is returned.
+func (dm *DDLMaker) GenerateFiles(ctx context.Context) (*Manifest, error) {
+	dm.logger().Info("start generate", "path", dm.config.OutFilePath)
+	if err := dm.parseIfNeeded(); err != nil {
+		return nil, err
+	}
+	warnings, err := dm.checkLint()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range warnings {
+		dm.logger().Info("lint", "diagnostic", d.String())
+	}
+
+	var manifest *Manifest
+	switch dm.config.Layout {
+	case LayoutTable, LayoutStatement:
+		manifest, err = dm.writeDir(ctx)
+	default:
+		manifest, err = dm.writeFile(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dm.logger().Info("done generate", "path", dm.config.OutFilePath, "files", len(manifest.Files))
+	return manifest, nil
+}
+
+// writeFile writes all tables into OutFilePath.
+func (dm *DDLMaker) writeFile(ctx context.Context) (*Manifest, error) {
+	file, err := os.Create(dm.config.OutFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error create ddl file: %w", err)
+	}
+	defer file.Close()
+
+	schema, err := dm.render(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("error generate: %w", err)
+	}
+	return &Manifest{
+		Layout: LayoutSingle,
+		Files:  []ManifestFile{{Path: dm.config.OutFilePath, Statements: len(schema.Statements)}},
+	}, nil
+}
+
+// outputFile is the content of the file written into OutFilePath directory.
+type outputFile struct {
+	ManifestFile
+	content string
+}
+
+// writeDir writes the files of the layout and the manifest into OutFilePath directory.
+// Files listed by the manifest of the previous generation that are not written again are removed.
+func (dm *DDLMaker) writeDir(ctx context.Context) (*Manifest, error) {
+	var files []outputFile
+	var err error
+	if dm.config.Layout == LayoutTable {
+		files, err = dm.tableFiles(ctx)
+	} else {
+		files, err = dm.statementFiles(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error generate: %w", err)
+	}
+
+	if err := os.MkdirAll(dm.config.OutFilePath, 0o755); err != nil {
+		return nil, fmt.Errorf("error create ddl directory: %w", err)
+	}
+	if err := removeStaleFiles(dm.config.OutFilePath, files); err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{Layout: dm.config.Layout}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dm.config.OutFilePath, f.Path), []byte(f.content), 0o644); err != nil {
+			return nil, fmt.Errorf("error create ddl file: %w", err)
+		}
+		manifest.Files = append(manifest.Files, f.ManifestFile)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dm.config.OutFilePath, ManifestFileName), append(data, '\n'), 0o644); err != nil {
+		return nil, fmt.Errorf("error create manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// removeStaleFiles removes files listed by the manifest in dir that are not in files.
+// Files that are not listed by the manifest are kept.
+func removeStaleFiles(dir string, files []outputFile) error {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error read manifest: %w", err)
+	}
+	var previous Manifest
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return fmt.Errorf("error decode manifest: %w", err)
+	}
+
+	written := make(map[string]bool, len(files))
+	for _, f := range files {
+		written[f.Path] = true
+	}
+	for _, f := range previous.Files {
+		// Only files directly under dir are written, so other paths are not removed.
+		if written[f.Path] || filepath.Base(f.Path) != f.Path {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, f.Path)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error remove stale ddl file: %w", err)
+		}
+	}
+	return nil
+}
+
+// tableFiles returns <table>.sql files that have header, the table and footer. Foreign keys of
+// cycles are written into the trailing file, which is applied after all tables are created.
+func (dm *DDLMaker) tableFiles(ctx context.Context) ([]outputFile, error) {
+	tmpls, err := dm.parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+	header, err := execute(tmpls.header, nil)
+	if err != nil {
+		return nil, fmt.Errorf("template header execute error: %w", err)
+	}
+	footer, err := execute(tmpls.footer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("template footer execute error: %w", err)
+	}
+
+	var files []outputFile
+	for _, table := range dm.Tables {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sql, err := execute(tmpls.table, table)
+		if err != nil {
+			return nil, fmt.Errorf("template execute error: %w", err)
+		}
+		name := unquote(table.Name())
+		content := header + sql + footer
+		files = append(files, outputFile{
+			ManifestFile: ManifestFile{Path: name + ".sql", Table: name, Statements: len(query.SplitStatements(content))},
+			content:      content,
+		})
+	}
+	if len(dm.deferred) > 0 {
+		content := header + foreignKeysSQL(dm.deferred) + footer
+		files = append(files, outputFile{
+			ManifestFile: ManifestFile{Path: foreignKeysFileName, Statements: len(query.SplitStatements(content))},
+			content:      content,
+		})
+	}
+	return files, nil
+}
+
+// statementFiles returns numbered files that have a statement each (e.g. 0001_header.sql, 0002_user.sql).
+func (dm *DDLMaker) statementFiles(ctx context.Context) ([]outputFile, error) {
+	tmpls, err := dm.parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []outputFile
+	add := func(sql, label, table string) {
+		for _, stmt := range query.SplitStatements(sql) {
+			files = append(files, outputFile{
+				ManifestFile: ManifestFile{
+					Path:       fmt.Sprintf("%04d_%s.sql", len(files)+1, label),
+					Table:      table,
+					Statements: 1,
+				},
+				content: stmt + "\n",
+			})
+		}
+	}
+
+	header, err := execute(tmpls.header, nil)
+	if err != nil {
+		return nil, fmt.Errorf("template header execute error: %w", err)
+	}
+	add(header, "header", "")
+	for _, table := range dm.Tables {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		sql, err := execute(tmpls.table, table)
+		if err != nil {
+			return nil, fmt.Errorf("template execute error: %w", err)
+		}
+		name := unquote(table.Name())
+		add(sql, name, name)
+	}
+	for _, fk := range dm.deferred {
+		add(fk.sql, fk.table+"_fk", fk.table)
+	}
+	footer, err := execute(tmpls.footer, nil)
+	if err != nil {
+		return nil, fmt.Errorf("template footer execute error: %w", err)
+	}
+	add(footer, "footer", "")
+	return files, nil
+}
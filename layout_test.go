@@ -0,0 +1,228 @@
+package ddlmaker
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type AccessLog struct {
+	ID   uint64 `ddl:"pk"`
+	Path string
+}
+
+type AccessToken struct {
+	ID    uint64 `ddl:"pk"`
+	Token string
+}
+
+func TestDDLMaker_GenerateFiles(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   []ManifestFile
+	}{
+		{
+			name:   "[Normal] table layout",
+			layout: LayoutTable,
+			want: []ManifestFile{
+				{Path: "access_log.sql", Table: "access_log", Statements: 4},
+				{Path: "access_token.sql", Table: "access_token", Statements: 4},
+			},
+		},
+		{
+			name:   "[Normal] statement layout",
+			layout: LayoutStatement,
+			want: []ManifestFile{
+				{Path: "0001_header.sql", Statements: 1},
+				{Path: "0002_access_log.sql", Table: "access_log", Statements: 1},
+				{Path: "0003_access_log.sql", Table: "access_log", Statements: 1},
+				{Path: "0004_access_token.sql", Table: "access_token", Statements: 1},
+				{Path: "0005_access_token.sql", Table: "access_token", Statements: 1},
+				{Path: "0006_footer.sql", Statements: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "sql")
+			dm, err := New(Config{
+				DB:          DBConfig{Driver: "mysql"},
+				OutFilePath: dir,
+				Layout:      tt.layout,
+				Logger:      &recordLogger{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := dm.AddStruct(AccessLog{}, AccessToken{}); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := dm.GenerateFiles(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := &Manifest{Layout: tt.layout, Files: tt.want}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var written Manifest
+			if err := json.Unmarshal(data, &written); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, &written); diff != "" {
+				t.Errorf("Compare manifest is mismatch (-want +got):%s\n", diff)
+			}
+			for _, f := range got.Files {
+				if _, err := os.Stat(filepath.Join(dir, f.Path)); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+
+	t.Run("[Normal] table file has header and footer", func(t *testing.T) {
+		dir := t.TempDir()
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, OutFilePath: dir, Layout: LayoutTable, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(AccessLog{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dm.GenerateFiles(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := os.ReadFile(filepath.Join(dir, "access_log.sql"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(got), "SET foreign_key_checks=0;\n") || !strings.HasSuffix(string(got), "SET foreign_key_checks=1;\n") {
+			t.Errorf("header or footer is not written:\n%s", got)
+		}
+	})
+
+	t.Run("[Normal] foreign keys of cycles are written into the trailing file", func(t *testing.T) {
+		dir := t.TempDir()
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, OutFilePath: dir, Layout: LayoutTable, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(ArticleComment{}, Article{}, Team{}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := dm.GenerateFiles(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := got.Files[len(got.Files)-1]
+		if diff := cmp.Diff(ManifestFile{Path: "_foreign_keys.sql", Statements: 3}, last); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+		team, err := os.ReadFile(filepath.Join(dir, "team.sql"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(team), "ALTER TABLE") {
+			t.Errorf("foreign key of cycle is written into the table file:\n%s", team)
+		}
+		fks, err := os.ReadFile(filepath.Join(dir, last.Path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(fks), "ALTER TABLE `team` ADD FOREIGN KEY (`article_id`) REFERENCES `article` (`id`);") {
+			t.Errorf("foreign key of cycle is not written:\n%s", fks)
+		}
+	})
+
+	t.Run("[Normal] files of the previous generation are removed", func(t *testing.T) {
+		dir := t.TempDir()
+		other := filepath.Join(dir, "README.md")
+		if err := os.WriteFile(other, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		generate := func(structs ...interface{}) {
+			dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, OutFilePath: dir, Layout: LayoutTable, Logger: &recordLogger{}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := dm.AddStruct(structs...); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := dm.GenerateFiles(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		generate(AccessLog{}, AccessToken{})
+		generate(AccessLog{})
+
+		if _, err := os.Stat(filepath.Join(dir, "access_token.sql")); !os.IsNotExist(err) {
+			t.Errorf("stale file is not removed: %v", err)
+		}
+		for _, path := range []string{"access_log.sql", other} {
+			if _, err := os.Stat(filepath.Join(dir, filepath.Base(path))); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	t.Run("[Normal] single layout", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "schema.sql")
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, OutFilePath: out, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(AccessLog{}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := dm.GenerateFiles(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &Manifest{Layout: LayoutSingle, Files: []ManifestFile{{Path: out, Statements: 4}}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] tables are written once after Lint and Generate", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "schema.sql")
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, OutFilePath: out, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(AccessLog{}, AccessToken{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dm.Lint(); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			if err := dm.Generate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(string(got), "CREATE TABLE"); n != 2 {
+			t.Errorf("mismatch CREATE TABLE want=2, got=%d:\n%s", n, got)
+		}
+	})
+}
@@ -0,0 +1,354 @@
+package ddlmaker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+)
+
+// Severity is the severity of the lint rule
+type Severity string
+
+const (
+	// SeverityOff disables the rule
+	SeverityOff Severity = "off"
+	// SeverityWarning reports the problem that does not make the DDL invalid
+	SeverityWarning Severity = "warning"
+	// SeverityError reports the problem that makes the DDL fail, and stops the generation
+	SeverityError Severity = "error"
+)
+
+const (
+	// RuleUnknownColumn reports primary key, index and foreign key columns that are not in the table
+	RuleUnknownColumn = "unknown-column"
+	// RuleDuplicateIndex reports index names that are used twice in the table
+	// (in the schema for PostgreSQL and SQLite)
+	RuleDuplicateIndex = "duplicate-index"
+	// RuleUnknownReferenceTable reports foreign keys that refer tables not added
+	RuleUnknownReferenceTable = "unknown-reference-table"
+	// RuleReservedWord reports column names that are SQL reserved words
+	RuleReservedWord = "reserved-word"
+	// RuleIdentifierLength reports table, column and index names longer than the limit of the dialect
+	RuleIdentifierLength = "identifier-length"
+	// RuleMissingPrimaryKey reports tables without primary key
+	RuleMissingPrimaryKey = "missing-primary-key"
+	// RuleIndexByteLimit reports MySQL indexes whose CHAR and VARCHAR columns exceed the byte limit of InnoDB
+	RuleIndexByteLimit = "index-byte-limit"
+)
+
+// defaultSeverities are severities of the rules that are not configured by Lint of the config.
+// All rules are warnings by default, so that the generation is stopped only by rules configured as error.
+var defaultSeverities = map[string]Severity{
+	RuleUnknownColumn:         SeverityWarning,
+	RuleDuplicateIndex:        SeverityWarning,
+	RuleUnknownReferenceTable: SeverityWarning,
+	RuleReservedWord:          SeverityWarning,
+	RuleIdentifierLength:      SeverityWarning,
+	RuleMissingPrimaryKey:     SeverityWarning,
+	RuleIndexByteLimit:        SeverityWarning,
+}
+
+// identifierLimits are the max lengths of identifiers of dialects. SQLite has no limit.
+var identifierLimits = map[string]int{
+	"mysql":     64,
+	"postgres":  63,
+	"sqlserver": 128,
+}
+
+const (
+	// indexByteLimit is the max bytes of the index of InnoDB with DYNAMIC or COMPRESSED row format
+	indexByteLimit = 3072
+	// compactIndexByteLimit is the max bytes of the index of InnoDB with COMPACT or REDUNDANT row format
+	compactIndexByteLimit = 767
+)
+
+// charsetBytes are the max bytes per character of MySQL charsets. Other charsets are single byte.
+var charsetBytes = map[string]int{
+	"utf8mb4": 4, "utf8mb3": 3, "utf8": 3, "utf16": 4, "utf16le": 4, "utf32": 4, "ucs2": 2,
+	"gb18030": 4, "ujis": 3, "eucjpms": 3, "big5": 2, "cp932": 2, "euckr": 2, "gb2312": 2, "gbk": 2, "sjis": 2,
+}
+
+// charColumn matches CHAR and VARCHAR columns with optional CHARACTER SET attribute.
+var charColumn = regexp.MustCompile(`(?i)^\S+ (?:VAR)?CHAR\((\d+)\)(?:.* CHARACTER SET (\w+))?`)
+
+// reservedWords are SQL keywords reserved by MySQL, PostgreSQL, SQLite or SQL Server.
+var reservedWords = wordSet(`
+	add all alter and any as asc between both by case cast check collate column constraint create
+	cross current_date current_time current_timestamp current_user database default delete desc
+	distinct drop else end except exists false fetch for foreign from full grant group having in
+	index inner insert intersect interval into is join key leading left like limit natural not
+	null offset on or order outer primary range rank references right row rows select session_user
+	set some table then to trailing true union unique update user using values when where window with`)
+
+// wordSet returns the set of the words separated by white spaces.
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// Diagnostic is the problem of the table found by Lint.
+type Diagnostic struct {
+	Rule     string
+	Severity Severity
+	// Table is the name of the table without quotes
+	Table   string
+	Message string
+}
+
+// String returns the diagnostic as "<severity>: <table>: <message> (<rule>)".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", d.Severity, d.Table, d.Message, d.Rule)
+}
+
+// validateLint returns error if rule IDs or severities of the lint config are unknown.
+func validateLint(lint map[string]string) error {
+	for rule, severity := range lint {
+		if _, ok := defaultSeverities[rule]; !ok {
+			return fmt.Errorf("%w: unknown lint rule %s", ErrInvalidConfig, rule)
+		}
+		switch Severity(severity) {
+		case SeverityOff, SeverityWarning, SeverityError:
+		default:
+			return fmt.Errorf("%w: unknown severity %s of lint rule %s", ErrInvalidConfig, severity, rule)
+		}
+	}
+	return nil
+}
+
+// Lint checks tables of added structs by the lint rules, and returns diagnostics in order of tables.
+// Severities of the rules are overridden by Lint of the config, and rules of SeverityOff are skipped.
+// Error is returned only if the structs can not be parsed.
+func (dm *DDLMaker) Lint() ([]Diagnostic, error) {
+	if err := dm.parseIfNeeded(); err != nil {
+		return nil, err
+	}
+
+	l := linter{
+		config: dm.config,
+		driver: dialect.Driver(dm.Dialect),
+		tables: make(map[string]dialect.Table, len(dm.Tables)),
+	}
+	for _, t := range dm.Tables {
+		l.tables[unquote(t.Name())] = t
+	}
+	indexes := make(map[string]string)
+	for _, t := range dm.Tables {
+		name := unquote(t.Name())
+		if l.driver != "postgres" && l.driver != "sqlite" {
+			indexes = make(map[string]string)
+		}
+		l.lintPrimaryKey(name, t)
+		l.lintIndexes(name, t, indexes)
+		l.lintForeignKeys(name, t)
+		l.lintIdentifiers(name, t)
+		l.lintIndexBytes(name, t)
+	}
+	return l.diagnostics, nil
+}
+
+// checkLint runs Lint, and returns diagnostics of SeverityWarning.
+// It returns *LintError if any diagnostic is SeverityError.
+func (dm *DDLMaker) checkLint() ([]Diagnostic, error) {
+	diagnostics, err := dm.Lint()
+	if err != nil {
+		return nil, err
+	}
+	var warnings, errs []Diagnostic
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		} else {
+			warnings = append(warnings, d)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, &LintError{Diagnostics: errs}
+	}
+	return warnings, nil
+}
+
+// linter checks tables, and collects diagnostics. Rules specific to the database are
+// decided by driver, which is the driver name of the dialect.
+type linter struct {
+	config      Config
+	driver      string
+	tables      map[string]dialect.Table
+	diagnostics []Diagnostic
+}
+
+// severity returns the severity of the rule configured by the config.
+func (l *linter) severity(rule string) Severity {
+	if severity, ok := l.config.Lint[rule]; ok {
+		return Severity(severity)
+	}
+	return defaultSeverities[rule]
+}
+
+// report adds the diagnostic if the rule is not off.
+func (l *linter) report(rule, table, format string, args ...interface{}) {
+	severity := l.severity(rule)
+	if severity == SeverityOff {
+		return
+	}
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		Rule:     rule,
+		Severity: severity,
+		Table:    table,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// hasColumn reports whether the table has the column.
+func hasColumn(t dialect.Table, column string) bool {
+	for _, c := range t.Columns() {
+		if unquote(c.Name()) == unquote(column) {
+			return true
+		}
+	}
+	return false
+}
+
+// lintPrimaryKey checks that the primary key is set and its columns are in the table.
+func (l *linter) lintPrimaryKey(name string, t dialect.Table) {
+	pk := t.PrimaryKey()
+	if pk == nil || len(pk.Columns()) == 0 {
+		l.report(RuleMissingPrimaryKey, name, "primary key is not set")
+		return
+	}
+	for _, c := range pk.Columns() {
+		if !hasColumn(t, c) {
+			l.report(RuleUnknownColumn, name, "primary key column %s is not in the table", c)
+		}
+	}
+}
+
+// lintIndexes checks names and columns of indexes. indexes maps index names into table names declaring them.
+func (l *linter) lintIndexes(name string, t dialect.Table, indexes map[string]string) {
+	for _, idx := range t.Indexes() {
+		if table, ok := indexes[idx.Name()]; ok {
+			if table == name {
+				l.report(RuleDuplicateIndex, name, "index %s is declared twice", idx.Name())
+			} else {
+				l.report(RuleDuplicateIndex, name, "index %s is declared in %s too", idx.Name(), table)
+			}
+		}
+		indexes[idx.Name()] = name
+		for _, c := range idx.Columns() {
+			if !hasColumn(t, c) {
+				l.report(RuleUnknownColumn, name, "column %s of index %s is not in the table", c, idx.Name())
+			}
+		}
+	}
+}
+
+// lintForeignKeys checks that columns of foreign keys and the referred tables exist.
+func (l *linter) lintForeignKeys(name string, t dialect.Table) {
+	for _, fk := range t.ForeignKeys() {
+		for _, c := range fk.ForeignColumns() {
+			if !hasColumn(t, c) {
+				l.report(RuleUnknownColumn, name, "foreign key column %s is not in the table", c)
+			}
+		}
+		ref := unquote(fk.ReferenceTableName())
+		refTable, ok := l.tables[ref]
+		if !ok {
+			l.report(RuleUnknownReferenceTable, name, "foreign key refers unknown table %s", ref)
+			continue
+		}
+		for _, c := range fk.ReferenceColumns() {
+			if !hasColumn(refTable, c) {
+				l.report(RuleUnknownColumn, name, "foreign key refers unknown column %s.%s", ref, c)
+			}
+		}
+	}
+}
+
+// lintIdentifiers checks lengths of identifiers and reserved words of column names.
+func (l *linter) lintIdentifiers(name string, t dialect.Table) {
+	limit := identifierLimits[l.driver]
+	tooLong := func(kind, identifier string) {
+		if limit > 0 && len(identifier) > limit {
+			l.report(RuleIdentifierLength, name, "%s name %s is longer than %d", kind, identifier, limit)
+		}
+	}
+
+	tooLong("table", name)
+	for _, c := range t.Columns() {
+		column := unquote(c.Name())
+		tooLong("column", column)
+		if reservedWords[strings.ToLower(column)] {
+			l.report(RuleReservedWord, name, "column name %s is a reserved word", column)
+		}
+	}
+	for _, idx := range t.Indexes() {
+		tooLong("index", idx.Name())
+	}
+}
+
+// lintIndexBytes checks the sum of max bytes of CHAR and VARCHAR columns of MySQL indexes.
+func (l *linter) lintIndexBytes(name string, t dialect.Table) {
+	if l.driver != "mysql" {
+		return
+	}
+	charset := t.Options().Charset
+	if charset == "" {
+		charset = l.config.DB.Charset
+	}
+	if charset == "" {
+		charset = "utf8mb4"
+	}
+	limit := indexByteLimit
+	switch strings.ToLower(t.Options().RowFormat) {
+	case "compact", "redundant":
+		limit = compactIndexByteLimit
+	}
+
+	columnBytes := make(map[string]int)
+	for _, c := range t.Columns() {
+		sql, err := c.ToSQL()
+		if err != nil {
+			continue
+		}
+		m := charColumn.FindStringSubmatch(sql)
+		if m == nil {
+			continue
+		}
+		size, _ := strconv.Atoi(m[1])
+		cs := charset
+		if m[2] != "" {
+			cs = m[2]
+		}
+		perChar, ok := charsetBytes[strings.ToLower(cs)]
+		if !ok {
+			perChar = 1
+		}
+		columnBytes[unquote(c.Name())] = size * perChar
+	}
+
+	check := func(kind string, columns []string) {
+		total := 0
+		for _, c := range columns {
+			total += columnBytes[unquote(c)]
+		}
+		if total > limit {
+			l.report(RuleIndexByteLimit, name, "%s is %d bytes, which exceeds %d bytes", kind, total, limit)
+		}
+	}
+	if pk := t.PrimaryKey(); pk != nil {
+		check("primary key", pk.Columns())
+	}
+	for _, idx := range t.Indexes() {
+		switch idx.(type) {
+		case mysql.FullTextIndex, mysql.SpatialIndex:
+			continue
+		}
+		check("index "+idx.Name(), idx.Columns())
+	}
+}
@@ -0,0 +1,247 @@
+package ddlmaker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+)
+
+type Shelf struct {
+	ID    uint64
+	Order int32
+	Label string `ddl:"size=1000"`
+}
+
+func (Shelf) PrimaryKey() dialect.PrimaryKey { return mysql.AddPrimaryKey("id") }
+
+func (Shelf) Indexes() dialect.Indexes {
+	return dialect.Indexes{
+		mysql.AddIndex("label_idx", "label"),
+		mysql.AddUniqueIndex("label_idx", "label", "order"),
+		mysql.AddFullTextIndex("label_ft_idx", "label"),
+	}
+}
+
+type Book struct {
+	ShelfID uint64
+	Title   string
+}
+
+func (Book) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{
+		mysql.AddForeignKey([]string{"player_id"}, []string{"id"}, "player"),
+		mysql.AddForeignKey([]string{"shelf_id"}, []string{"uuid"}, "shelf"),
+	}
+}
+
+type Bin struct {
+	ID uint64
+}
+
+func (Bin) PrimaryKey() dialect.PrimaryKey { return postgres.AddPrimaryKey("id") }
+
+func (Bin) Indexes() dialect.Indexes {
+	return dialect.Indexes{postgres.AddIndex("id_idx", "bin", "id")}
+}
+
+type Box struct {
+	ID uint64
+}
+
+func (Box) PrimaryKey() dialect.PrimaryKey { return postgres.AddPrimaryKey("id") }
+
+func (Box) Indexes() dialect.Indexes {
+	return dialect.Indexes{postgres.AddIndex("id_idx", "box", "id")}
+}
+
+type Tray struct {
+	ID                                                             uint64 `ddl:"pk"`
+	ColumnNameLongerThanTheLimitOfSixtyFourCharactersOfIdentifiers string
+}
+
+func TestDDLMaker_Lint(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		lint    map[string]string
+		structs []interface{}
+		want    []Diagnostic
+	}{
+		{
+			name:    "[Normal] default severities",
+			driver:  "mysql",
+			structs: []interface{}{Shelf{}, Book{}},
+			want: []Diagnostic{
+				{Rule: RuleDuplicateIndex, Severity: SeverityWarning, Table: "shelf", Message: "index label_idx is declared twice"},
+				{Rule: RuleReservedWord, Severity: SeverityWarning, Table: "shelf", Message: "column name order is a reserved word"},
+				{Rule: RuleIndexByteLimit, Severity: SeverityWarning, Table: "shelf", Message: "index label_idx is 4000 bytes, which exceeds 3072 bytes"},
+				{Rule: RuleIndexByteLimit, Severity: SeverityWarning, Table: "shelf", Message: "index label_idx is 4000 bytes, which exceeds 3072 bytes"},
+				{Rule: RuleMissingPrimaryKey, Severity: SeverityWarning, Table: "book", Message: "primary key is not set"},
+				{Rule: RuleUnknownColumn, Severity: SeverityWarning, Table: "book", Message: "foreign key column player_id is not in the table"},
+				{Rule: RuleUnknownReferenceTable, Severity: SeverityWarning, Table: "book", Message: "foreign key refers unknown table player"},
+				{Rule: RuleUnknownColumn, Severity: SeverityWarning, Table: "book", Message: "foreign key refers unknown column shelf.uuid"},
+			},
+		},
+		{
+			name:   "[Normal] severities are configured",
+			driver: "mysql",
+			lint: map[string]string{
+				RuleMissingPrimaryKey:     "off",
+				RuleUnknownReferenceTable: "off",
+				RuleUnknownColumn:         "error",
+			},
+			structs: []interface{}{Book{}},
+			want: []Diagnostic{
+				{Rule: RuleUnknownColumn, Severity: SeverityError, Table: "book", Message: "foreign key column player_id is not in the table"},
+			},
+		},
+		{
+			name:    "[Normal] identifier length",
+			driver:  "mysql",
+			structs: []interface{}{Tray{}},
+			want: []Diagnostic{
+				{
+					Rule: RuleIdentifierLength, Severity: SeverityWarning, Table: "tray",
+					Message: "column name column_name_longer_than_the_limit_of_sixty_four_characters_of_identifiers is longer than 64",
+				},
+			},
+		},
+		{
+			name:    "[Normal] index names are unique in the schema of PostgreSQL",
+			driver:  "postgres",
+			structs: []interface{}{Bin{}, Box{}},
+			want: []Diagnostic{
+				{Rule: RuleDuplicateIndex, Severity: SeverityWarning, Table: "box", Message: "index id_idx is declared in bin too"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm, err := New(Config{DB: DBConfig{Driver: tt.driver}, Lint: tt.lint, Logger: &recordLogger{}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := dm.AddStruct(tt.structs...); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := dm.Lint()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+
+	t.Run("[Normal] rules of the dialect without New", func(t *testing.T) {
+		dm := &DDLMaker{Dialect: mysql.MySQL{}}
+		if err := dm.AddStruct(Tray{}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := dm.Lint()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Diagnostic{
+			{
+				Rule: RuleIdentifierLength, Severity: SeverityWarning, Table: "tray",
+				Message: "column name column_name_longer_than_the_limit_of_sixty_four_characters_of_identifiers is longer than 64",
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	tests2 := []struct {
+		name string
+		lint map[string]string
+	}{
+		{name: "[Error] unknown rule", lint: map[string]string{"no-such-rule": "error"}},
+		{name: "[Error] unknown severity", lint: map[string]string{RuleReservedWord: "fatal"}},
+	}
+	for _, tt := range tests2 {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(Config{DB: DBConfig{Driver: "mysql"}, Lint: tt.lint})
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("mismatch want=%v, got=%v", ErrInvalidConfig, err)
+			}
+		})
+	}
+}
+
+func TestDDLMaker_GenerateLint(t *testing.T) {
+	blocking := map[string]string{RuleIdentifierLength: "error"}
+
+	t.Run("[Normal] warnings do not stop the generation", func(t *testing.T) {
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(Article{}); err != nil {
+			t.Fatal(err)
+		}
+
+		schema, err := dm.GenerateTo(context.Background(), &bytes.Buffer{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"warning: article: foreign key refers unknown table team (unknown-reference-table)"}
+		if diff := cmp.Diff(want, schema.Warnings); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Error] errors stop GenerateTo", func(t *testing.T) {
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, Lint: blocking, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(Tray{}); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		_, err = dm.GenerateTo(context.Background(), &buf)
+		var lintErr *LintError
+		if !errors.As(err, &lintErr) {
+			t.Fatalf("mismatch want=*LintError, got=%v", err)
+		}
+		if len(lintErr.Diagnostics) != 1 || lintErr.Diagnostics[0].Rule != RuleIdentifierLength {
+			t.Errorf("unexpected diagnostics: %v", lintErr.Diagnostics)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("DDL is written: %s", buf.String())
+		}
+	})
+
+	t.Run("[Error] errors stop Generate", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "schema.sql")
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, OutFilePath: out, Lint: blocking, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(Tray{}); err != nil {
+			t.Fatal(err)
+		}
+
+		var lintErr *LintError
+		if err := dm.Generate(); !errors.As(err, &lintErr) {
+			t.Fatalf("mismatch want=*LintError, got=%v", err)
+		}
+		if _, err := os.Stat(out); !os.IsNotExist(err) {
+			t.Errorf("file is written: %v", err)
+		}
+	})
+}
@@ -0,0 +1,385 @@
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+)
+
+// rebuildTableName is temporary table name used when the table is rebuilt.
+const rebuildTableName = "_ddlmaker_rebuild"
+
+// Statements around the rebuild of the table. Foreign keys are disabled so that
+// dropping the old table does not delete or fail on rows of child tables, and
+// are checked before they are enabled again. SQLite ignores PRAGMA foreign_keys
+// inside a transaction, so these statements must be executed outside of it.
+const (
+	disableForeignKeysSQL = "PRAGMA foreign_keys=OFF;"
+	checkForeignKeysSQL   = "PRAGMA foreign_key_check;"
+	enableForeignKeysSQL  = "PRAGMA foreign_keys=ON;"
+)
+
+// ErrNotSupported means the dialect can not generate migration statements
+var ErrNotSupported = errors.New("dialect does not support migration")
+
+// ErrUnnamedForeignKey means the foreign key to drop is declared without constraint name
+var ErrUnnamedForeignKey = errors.New("foreign key can not be dropped without name")
+
+// tableDiff is the difference between old and new definitions of a table.
+type tableDiff struct {
+	from dialect.Table
+	to   dialect.Table
+
+	addColumns    []addColumn
+	modifyColumns []string
+	dropColumns   []string
+	addIndexes    dialect.Indexes
+	dropIndexes   dialect.Indexes
+	addFKs        dialect.ForeignKeys
+	dropFKs       []string
+	pkChanged     bool
+	rebuild       bool
+}
+
+// addColumn is the column added to the table.
+type addColumn struct {
+	sql  string
+	spec spec.Column
+}
+
+// Diff compares tables of from and to, and returns ordered statements that
+// migrate the schema of from into the schema of to. Tables are matched by name.
+//
+// Foreign keys are dropped before indexes and columns are changed, and added
+// after them. When the dialect can not alter the table (e.g. SQLite), the
+// table is rebuilt with foreign keys disabled: a new table is created, rows
+// are copied, the old table is dropped and the new one is renamed, then
+// foreign keys are checked and enabled again. The statements of the rebuild
+// must not be executed in a transaction, where SQLite can not disable foreign keys.
+func Diff(d dialect.Dialect, from, to []dialect.Table) ([]string, error) {
+	m, ok := d.(dialect.Migrator)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotSupported, d)
+	}
+
+	tmpl, err := template.New("ddl").Parse(d.TableTemplate())
+	if err != nil {
+		return nil, fmt.Errorf("error parse ddl template: %w", err)
+	}
+
+	fromTables := make(map[string]dialect.Table, len(from))
+	for _, t := range from {
+		fromTables[t.Name()] = t
+	}
+	toTables := make(map[string]dialect.Table, len(to))
+	for _, t := range to {
+		toTables[t.Name()] = t
+	}
+
+	var diffs []*tableDiff
+	for _, t := range to {
+		old, ok := fromTables[t.Name()]
+		if !ok {
+			continue
+		}
+		td, err := diffTable(m, old, t)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, td)
+	}
+
+	var stmts []string
+
+	for _, td := range diffs {
+		if td.rebuild {
+			continue
+		}
+		for _, fk := range td.dropFKs {
+			stmts = append(stmts, m.DropForeignKeySQL(td.to.Name(), fk))
+		}
+	}
+	for _, td := range diffs {
+		if td.rebuild {
+			continue
+		}
+		for _, idx := range td.dropIndexes {
+			stmts = append(stmts, m.DropIndexSQL(td.to.Name(), idx.Name()))
+		}
+	}
+	for _, t := range from {
+		if _, ok := toTables[t.Name()]; !ok {
+			stmts = append(stmts, m.DropTableSQL(t.Name()))
+		}
+	}
+	for _, t := range to {
+		if _, ok := fromTables[t.Name()]; ok {
+			continue
+		}
+		create, err := createTable(m, tmpl, t)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, create...)
+	}
+	for _, td := range diffs {
+		if td.rebuild {
+			rebuild, err := rebuildTable(m, d, tmpl, td)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, rebuild...)
+			continue
+		}
+		stmts = append(stmts, alterTable(m, td)...)
+	}
+	for _, td := range diffs {
+		if td.rebuild {
+			continue
+		}
+		for _, idx := range td.addIndexes {
+			stmts = append(stmts, m.AddIndexSQL(td.to.Name(), idx.ToSQL()))
+		}
+	}
+	for _, td := range diffs {
+		if td.rebuild {
+			continue
+		}
+		for _, fk := range td.addFKs {
+			stmts = append(stmts, m.AddForeignKeySQL(td.to.Name(), fk.ToSQL()))
+		}
+	}
+
+	return stmts, nil
+}
+
+// diffTable compares old and new definitions of the table.
+func diffTable(m dialect.Migrator, from, to dialect.Table) (*tableDiff, error) {
+	td := &tableDiff{from: from, to: to}
+	name := to.Name()
+
+	fromColumns := make(map[string]string, len(from.Columns()))
+	for _, c := range from.Columns() {
+		sql, err := c.ToSQL()
+		if err != nil {
+			return nil, fmt.Errorf("error column %s of %s to sql: %w", c.Name(), from.Name(), err)
+		}
+		fromColumns[c.Name()] = sql
+	}
+	toColumns := make(map[string]struct{}, len(to.Columns()))
+	for _, c := range to.Columns() {
+		toColumns[c.Name()] = struct{}{}
+		sql, err := c.ToSQL()
+		if err != nil {
+			return nil, fmt.Errorf("error column %s of %s to sql: %w", c.Name(), to.Name(), err)
+		}
+		old, ok := fromColumns[c.Name()]
+		switch {
+		case !ok:
+			ac, err := newAddColumn(c, sql, to)
+			if err != nil {
+				return nil, err
+			}
+			td.addColumns = append(td.addColumns, ac)
+			td.rebuild = td.rebuild || m.AddColumnSQL(name, ac.sql, ac.spec) == ""
+		case old != sql:
+			td.modifyColumns = append(td.modifyColumns, sql)
+			td.rebuild = td.rebuild || m.ModifyColumnSQL(name, sql) == ""
+		}
+	}
+	for _, c := range from.Columns() {
+		if _, ok := toColumns[c.Name()]; !ok {
+			td.dropColumns = append(td.dropColumns, c.Name())
+			td.rebuild = td.rebuild || m.DropColumnSQL(name, c.Name()) == ""
+		}
+	}
+
+	fromIndexes := make(map[string]string, len(from.Indexes()))
+	for _, idx := range from.Indexes() {
+		fromIndexes[idx.Name()] = idx.ToSQL()
+	}
+	toIndexes := make(map[string]string, len(to.Indexes()))
+	for _, idx := range to.Indexes().Sort() {
+		toIndexes[idx.Name()] = idx.ToSQL()
+		if old, ok := fromIndexes[idx.Name()]; !ok || old != idx.ToSQL() {
+			td.addIndexes = append(td.addIndexes, idx)
+		}
+	}
+	for _, idx := range from.Indexes().Sort() {
+		if sql, ok := toIndexes[idx.Name()]; !ok || sql != idx.ToSQL() {
+			td.dropIndexes = append(td.dropIndexes, idx)
+		}
+	}
+
+	fromFKs := make(map[string]struct{}, len(from.ForeignKeys()))
+	for _, fk := range from.ForeignKeys() {
+		fromFKs[fk.ToSQL()] = struct{}{}
+	}
+	toFKs := make(map[string]struct{}, len(to.ForeignKeys()))
+	for _, fk := range to.ForeignKeys().Sort() {
+		toFKs[fk.ToSQL()] = struct{}{}
+		if _, ok := fromFKs[fk.ToSQL()]; !ok {
+			td.addFKs = append(td.addFKs, fk)
+			td.rebuild = td.rebuild || m.AddForeignKeySQL(name, fk.ToSQL()) == ""
+		}
+	}
+	for _, fk := range from.ForeignKeys().Sort() {
+		if _, ok := toFKs[fk.ToSQL()]; ok {
+			continue
+		}
+		var fkName string
+		if v, ok := fk.(dialect.NamedForeignKey); ok {
+			fkName = v.Name()
+		}
+		if m.DropForeignKeySQL(name, fkName) == "" {
+			td.rebuild = true
+			continue
+		}
+		if fkName == "" {
+			return nil, fmt.Errorf("%w: %s of %s", ErrUnnamedForeignKey, fk.ToSQL(), from.Name())
+		}
+		td.dropFKs = append(td.dropFKs, fkName)
+	}
+
+	if primaryKeySQL(from) != primaryKeySQL(to) {
+		td.pkChanged = true
+		if primaryKeySQL(from) != "" {
+			td.rebuild = td.rebuild || m.DropPrimaryKeySQL(name) == ""
+		}
+		if primaryKeySQL(to) != "" {
+			td.rebuild = td.rebuild || m.AddPrimaryKeySQL(name, primaryKeySQL(to)) == ""
+		}
+	}
+
+	return td, nil
+}
+
+// newAddColumn returns the column added to the table t with its parsed definition.
+// The definition is zero value if the column does not implement dialect.ColumnSpec.
+func newAddColumn(c dialect.Column, sql string, t dialect.Table) (addColumn, error) {
+	ac := addColumn{sql: sql}
+	if cs, ok := c.(dialect.ColumnSpec); ok {
+		s, err := cs.Spec()
+		if err != nil {
+			return ac, fmt.Errorf("error spec of column %s of %s: %w", c.Name(), t.Name(), err)
+		}
+		ac.spec = s
+	}
+	if pk := t.PrimaryKey(); pk != nil {
+		for _, column := range pk.Columns() {
+			if column == c.Name() {
+				ac.spec.PrimaryKey = true
+			}
+		}
+	}
+	return ac, nil
+}
+
+// alterTable returns ALTER TABLE statements for columns and primary key.
+func alterTable(m dialect.Migrator, td *tableDiff) []string {
+	var stmts []string
+	name := td.to.Name()
+
+	if td.pkChanged && primaryKeySQL(td.from) != "" {
+		stmts = append(stmts, m.DropPrimaryKeySQL(name))
+	}
+	for _, c := range td.addColumns {
+		stmts = append(stmts, m.AddColumnSQL(name, c.sql, c.spec))
+	}
+	for _, sql := range td.modifyColumns {
+		stmts = append(stmts, m.ModifyColumnSQL(name, sql))
+	}
+	for _, column := range td.dropColumns {
+		stmts = append(stmts, m.DropColumnSQL(name, column))
+	}
+	if td.pkChanged && primaryKeySQL(td.to) != "" {
+		stmts = append(stmts, m.AddPrimaryKeySQL(name, primaryKeySQL(td.to)))
+	}
+
+	return stmts
+}
+
+// rebuildTable returns statements that recreate the table with new definition
+// and copy rows of columns that exist in both definitions, while foreign keys are disabled.
+func rebuildTable(m dialect.Migrator, d dialect.Dialect, tmpl *template.Template, td *tableDiff) ([]string, error) {
+	tmp := renamedTable{Table: td.to, name: d.Quote(rebuildTableName)}
+	create, err := createTable(m, tmpl, tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	fromColumns := make(map[string]struct{}, len(td.from.Columns()))
+	for _, c := range td.from.Columns() {
+		fromColumns[c.Name()] = struct{}{}
+	}
+	var columns []string
+	for _, c := range td.to.Columns() {
+		if _, ok := fromColumns[c.Name()]; ok {
+			columns = append(columns, d.Quote(c.Name()))
+		}
+	}
+
+	stmts := append([]string{disableForeignKeysSQL}, create...)
+	if len(columns) > 0 {
+		stmts = append(stmts, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s;",
+			tmp.Name(), strings.Join(columns, ", "), strings.Join(columns, ", "), td.from.Name()))
+	}
+	stmts = append(stmts,
+		m.DropTableSQL(td.from.Name()),
+		m.RenameTableSQL(tmp.Name(), td.to.Name()))
+	for _, idx := range td.to.Indexes().Sort() {
+		stmts = append(stmts, m.AddIndexSQL(td.to.Name(), idx.ToSQL()))
+	}
+	stmts = append(stmts, checkForeignKeysSQL, enableForeignKeysSQL)
+
+	return stmts, nil
+}
+
+// renamedTable is the table renamed to the temporary name without indexes.
+// Indexes are created after the table is renamed.
+type renamedTable struct {
+	dialect.Table
+	name string
+}
+
+func (t renamedTable) Name() string {
+	return t.name
+}
+
+func (t renamedTable) Indexes() dialect.Indexes {
+	return nil
+}
+
+// createTable returns the statements of the table template. DROP TABLE of the
+// template is returned as a separate statement from CREATE TABLE.
+func createTable(m dialect.Migrator, tmpl *template.Template, t dialect.Table) ([]string, error) {
+	sql, err := execute(tmpl, t)
+	if err != nil {
+		return nil, err
+	}
+	drop := m.DropTableSQL(t.Name())
+	if !strings.HasPrefix(sql, drop) {
+		return []string{sql}, nil
+	}
+	return []string{drop, strings.TrimSpace(strings.TrimPrefix(sql, drop))}, nil
+}
+
+func execute(tmpl *template.Template, t dialect.Table) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, t); err != nil {
+		return "", fmt.Errorf("template execute error: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func primaryKeySQL(t dialect.Table) string {
+	if t.PrimaryKey() == nil {
+		return ""
+	}
+	return t.PrimaryKey().ToSQL()
+}
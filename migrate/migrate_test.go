@@ -0,0 +1,427 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mock"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+type testColumn struct {
+	name string
+	sql  string
+}
+
+func (c testColumn) Name() string           { return c.name }
+func (c testColumn) Comment() string        { return c.name }
+func (c testColumn) ToSQL() (string, error) { return c.sql, nil }
+
+// specColumn is testColumn with its parsed definition.
+type specColumn struct {
+	testColumn
+	spec spec.Column
+}
+
+func (c specColumn) Spec() (spec.Column, error) { return c.spec, nil }
+
+type testTable struct {
+	name        string
+	primaryKey  dialect.PrimaryKey
+	foreignKeys dialect.ForeignKeys
+	columns     []dialect.Column
+	indexes     dialect.Indexes
+	dialect     dialect.Dialect
+}
+
+func (t testTable) Name() string                     { return t.dialect.Quote(t.name) }
+func (t testTable) PrimaryKey() dialect.PrimaryKey   { return t.primaryKey }
+func (t testTable) ForeignKeys() dialect.ForeignKeys { return t.foreignKeys }
+func (t testTable) Columns() []dialect.Column        { return t.columns }
+func (t testTable) Indexes() dialect.Indexes         { return t.indexes }
+func (t testTable) Checks() dialect.Checks           { return nil }
+func (t testTable) Options() dialect.TableOptions    { return dialect.TableOptions{} }
+func (t testTable) Dialect() dialect.Dialect         { return t.dialect }
+
+func TestDiff_MySQL(t *testing.T) {
+	d := mysql.MySQL{Engine: "InnoDB", Charset: "utf8mb4"}
+
+	from := []dialect.Table{
+		testTable{
+			name:       "player",
+			primaryKey: mysql.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+				testColumn{"name", "`name` VARCHAR(191) NOT NULL"},
+				testColumn{"token", "`token` VARCHAR(191) NOT NULL"},
+			},
+			indexes: dialect.Indexes{
+				mysql.AddIndex("token_idx", "token"),
+			},
+			dialect: d,
+		},
+		testTable{
+			name:       "entry",
+			primaryKey: mysql.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+				testColumn{"player_id", "`player_id` BIGINT unsigned NOT NULL"},
+			},
+			foreignKeys: dialect.ForeignKeys{
+				mysql.AddForeignKey([]string{"player_id"}, []string{"id"}, "player",
+					mysql.WithNameForeignKeyOption("fk_entry_player_id")),
+			},
+			dialect: d,
+		},
+		testTable{
+			name:       "legacy",
+			primaryKey: mysql.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+			},
+			dialect: d,
+		},
+	}
+
+	to := []dialect.Table{
+		testTable{
+			name:       "player",
+			primaryKey: mysql.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+				testColumn{"name", "`name` VARCHAR(100) NOT NULL"},
+				testColumn{"email", "`email` VARCHAR(191) NOT NULL"},
+			},
+			indexes: dialect.Indexes{
+				mysql.AddUniqueIndex("email_uniq_idx", "email"),
+			},
+			dialect: d,
+		},
+		testTable{
+			name:       "entry",
+			primaryKey: mysql.AddPrimaryKey("id", "player_id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+				testColumn{"player_id", "`player_id` BIGINT unsigned NOT NULL"},
+			},
+			foreignKeys: dialect.ForeignKeys{
+				mysql.AddForeignKey([]string{"player_id"}, []string{"id"}, "player",
+					mysql.WithNameForeignKeyOption("fk_entry_player_id"),
+					mysql.WithDeleteForeignKeyOption(mysql.ForeignKeyOptionCascade)),
+			},
+			dialect: d,
+		},
+	}
+
+	got, err := Diff(d, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"ALTER TABLE `entry` DROP FOREIGN KEY `fk_entry_player_id`;",
+		"ALTER TABLE `player` DROP INDEX `token_idx`;",
+		"DROP TABLE IF EXISTS `legacy`;",
+		"ALTER TABLE `player` ADD COLUMN `email` VARCHAR(191) NOT NULL;",
+		"ALTER TABLE `player` MODIFY COLUMN `name` VARCHAR(100) NOT NULL;",
+		"ALTER TABLE `player` DROP COLUMN `token`;",
+		"ALTER TABLE `entry` DROP PRIMARY KEY;",
+		"ALTER TABLE `entry` ADD PRIMARY KEY (`id`, `player_id`);",
+		"ALTER TABLE `player` ADD UNIQUE `email_uniq_idx` (`email`);",
+		"ALTER TABLE `entry` ADD CONSTRAINT `fk_entry_player_id` FOREIGN KEY (`player_id`) REFERENCES `player` (`id`) ON DELETE CASCADE;",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+}
+
+func TestDiff_MySQLCreateTable(t *testing.T) {
+	d := mysql.MySQL{Engine: "InnoDB", Charset: "utf8mb4"}
+
+	to := []dialect.Table{
+		testTable{
+			name:       "player",
+			primaryKey: mysql.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+			},
+			dialect: d,
+		},
+	}
+
+	got, err := Diff(d, nil, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"DROP TABLE IF EXISTS `player`;",
+		"CREATE TABLE `player` (\n" +
+			"    `id` BIGINT unsigned NOT NULL,\n" +
+			"    PRIMARY KEY (`id`)\n" +
+			") ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4;",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+}
+
+func TestDiff_SQLite(t *testing.T) {
+	d := sqlite.SQLite{}
+
+	t.Run("[Normal] add nullable column and index by ALTER TABLE", func(t *testing.T) {
+		from := []dialect.Table{
+			testTable{
+				name:       "player",
+				primaryKey: sqlite.AddPrimaryKey("id"),
+				columns: []dialect.Column{
+					testColumn{"id", "`id` INTEGER NOT NULL"},
+				},
+				indexes: dialect.Indexes{
+					sqlite.AddIndex("old_idx", "player", "id"),
+				},
+				dialect: d,
+			},
+		}
+		to := []dialect.Table{
+			testTable{
+				name:       "player",
+				primaryKey: sqlite.AddPrimaryKey("id"),
+				columns: []dialect.Column{
+					testColumn{"id", "`id` INTEGER NOT NULL"},
+					specColumn{testColumn{"name", "`name` TEXT NULL"}, spec.Column{Attributes: spec.Attributes{Null: true}}},
+				},
+				indexes: dialect.Indexes{
+					sqlite.AddIndex("name_idx", "player", "name"),
+				},
+				dialect: d,
+			},
+		}
+
+		got, err := Diff(d, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{
+			"DROP INDEX IF EXISTS `old_idx`;",
+			"ALTER TABLE `player` ADD COLUMN `name` TEXT NULL;",
+			"CREATE INDEX `name_idx` ON `player` (`name`);",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] rebuild table when column is modified", func(t *testing.T) {
+		from := []dialect.Table{
+			testTable{
+				name:       "entry",
+				primaryKey: sqlite.AddPrimaryKey("id"),
+				columns: []dialect.Column{
+					testColumn{"id", "`id` INTEGER NOT NULL"},
+					testColumn{"title", "`title` TEXT NULL"},
+					testColumn{"body", "`body` TEXT NULL"},
+				},
+				dialect: d,
+			},
+		}
+		to := []dialect.Table{
+			testTable{
+				name:       "entry",
+				primaryKey: sqlite.AddPrimaryKey("id"),
+				columns: []dialect.Column{
+					testColumn{"id", "`id` INTEGER NOT NULL"},
+					testColumn{"title", "`title` TEXT NOT NULL"},
+				},
+				indexes: dialect.Indexes{
+					sqlite.AddIndex("title_idx", "entry", "title"),
+				},
+				dialect: d,
+			},
+		}
+
+		got, err := Diff(d, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{
+			"PRAGMA foreign_keys=OFF;",
+			"DROP TABLE IF EXISTS `_ddlmaker_rebuild`;",
+			"CREATE TABLE `_ddlmaker_rebuild` (\n" +
+				"    `id` INTEGER NOT NULL,\n" +
+				"    `title` TEXT NOT NULL,\n" +
+				"    PRIMARY KEY (`id`)\n" +
+				");",
+			"INSERT INTO `_ddlmaker_rebuild` (`id`, `title`) SELECT `id`, `title` FROM `entry`;",
+			"DROP TABLE IF EXISTS `entry`;",
+			"ALTER TABLE `_ddlmaker_rebuild` RENAME TO `entry`;",
+			"CREATE INDEX `title_idx` ON `entry` (`title`);",
+			"PRAGMA foreign_key_check;",
+			"PRAGMA foreign_keys=ON;",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+}
+
+func TestDiff_SQLiteAddColumn(t *testing.T) {
+	d := sqlite.SQLite{}
+	from := []dialect.Table{
+		testTable{
+			name:       "player",
+			primaryKey: sqlite.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` INTEGER NOT NULL"},
+			},
+			dialect: d,
+		},
+	}
+	alter := []string{"ALTER TABLE `player` ADD COLUMN `added` INTEGER;"}
+
+	tests := []struct {
+		name       string
+		column     dialect.Column
+		primaryKey []string
+		rebuild    bool
+	}{
+		{
+			name:   "[Normal] nullable column is added",
+			column: specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Null: true}}},
+		},
+		{
+			name:   "[Normal] NOT NULL column with constant default is added",
+			column: specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Default: "0"}}},
+		},
+		{
+			name:   "[Normal] VIRTUAL generated column is added",
+			column: specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Generated: "`id` + 1"}},
+		},
+		{
+			name:    "[Normal] column without definition is rebuilt",
+			column:  testColumn{"added", "`added` INTEGER"},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] NOT NULL column without default is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{}},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] NOT NULL column with NULL default is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Default: "null"}}},
+			rebuild: true,
+		},
+		{
+			name:       "[Normal] PRIMARY KEY column is rebuilt",
+			column:     specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Null: true}}},
+			primaryKey: []string{"id", "added"},
+			rebuild:    true,
+		},
+		{
+			name:    "[Normal] UNIQUE column is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Null: true}, Unique: true}},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] STORED generated column is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Generated: "`id` + 1", Stored: true}},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] column with CURRENT_TIMESTAMP default is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Default: "CURRENT_TIMESTAMP"}}},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] column with current_time default is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Null: true, Default: "current_time"}}},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] column with CURRENT_DATE default is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Default: "CURRENT_DATE"}}},
+			rebuild: true,
+		},
+		{
+			name:    "[Normal] column with parenthesized default is rebuilt",
+			column:  specColumn{testColumn{"added", "`added` INTEGER"}, spec.Column{Attributes: spec.Attributes{Default: "(random())"}}},
+			rebuild: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryKey := []string{"id"}
+			if tt.primaryKey != nil {
+				primaryKey = tt.primaryKey
+			}
+			to := []dialect.Table{
+				testTable{
+					name:       "player",
+					primaryKey: sqlite.AddPrimaryKey(primaryKey...),
+					columns: []dialect.Column{
+						testColumn{"id", "`id` INTEGER NOT NULL"},
+						tt.column,
+					},
+					dialect: d,
+				},
+			}
+
+			got, err := Diff(d, from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.rebuild {
+				rename := "ALTER TABLE `_ddlmaker_rebuild` RENAME TO `player`;"
+				for _, stmt := range got {
+					if stmt == rename {
+						return
+					}
+				}
+				t.Errorf("table is not rebuilt: %q", got)
+				return
+			}
+			if diff := cmp.Diff(alter, got); diff != "" {
+				t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDiff_UnnamedForeignKey(t *testing.T) {
+	d := mysql.MySQL{Engine: "InnoDB", Charset: "utf8mb4"}
+	table := func(fk dialect.ForeignKey) dialect.Table {
+		return testTable{
+			name:       "entry",
+			primaryKey: mysql.AddPrimaryKey("id"),
+			columns: []dialect.Column{
+				testColumn{"id", "`id` BIGINT unsigned NOT NULL"},
+				testColumn{"player_id", "`player_id` BIGINT unsigned NOT NULL"},
+			},
+			foreignKeys: dialect.ForeignKeys{fk},
+			dialect:     d,
+		}
+	}
+	from := []dialect.Table{table(mysql.AddForeignKey([]string{"player_id"}, []string{"id"}, "player"))}
+	to := []dialect.Table{table(mysql.AddForeignKey([]string{"player_id"}, []string{"id"}, "player",
+		mysql.WithNameForeignKeyOption("fk_entry_player_id")))}
+
+	_, err := Diff(d, from, to)
+	if !errors.Is(err, ErrUnnamedForeignKey) {
+		t.Errorf("mismatch want=%v, got=%v", ErrUnnamedForeignKey, err)
+	}
+}
+
+func TestDiff_NotSupported(t *testing.T) {
+	_, err := Diff(mock.SQLMock{}, nil, nil)
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("mismatch want=%v, got=%v", ErrNotSupported, err)
+	}
+}
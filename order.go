@@ -0,0 +1,134 @@
+package ddlmaker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+)
+
+// deferredForeignKey is the foreign key of a cycle. It is added by ALTER TABLE after all tables are created.
+type deferredForeignKey struct {
+	// table is the name of the table that has the foreign key without quotes
+	table string
+	// sql is ALTER TABLE statement that adds the foreign key
+	sql string
+}
+
+// cyclicTable is the table whose foreign keys of cycles are removed from CREATE TABLE.
+type cyclicTable struct {
+	dialect.Table
+	foreignKeys dialect.ForeignKeys
+}
+
+// ForeignKeys returns foreign keys that are not deferred.
+func (t cyclicTable) ForeignKeys() dialect.ForeignKeys {
+	return t.foreignKeys
+}
+
+// sortTables sorts tables so that referenced tables are created before the tables referencing them.
+// The order of tables is kept if they do not reference each other. Foreign keys that make a cycle
+// are removed from the table and returned as ALTER TABLE statements. They are kept in the table if
+// the dialect can not add foreign keys by ALTER TABLE (e.g. SQLite, which allows references to
+// tables that are not created yet). Self references and references to unknown tables are ignored.
+func sortTables(tables []dialect.Table, d dialect.Dialect) ([]dialect.Table, []deferredForeignKey) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	byName := make(map[string][]int, len(tables))
+	for i, t := range tables {
+		name := unquote(t.Name())
+		byName[name] = append(byName[name], i)
+	}
+
+	state := make([]int, len(tables))
+	cyclic := make([]map[int]bool, len(tables))
+	order := make([]int, 0, len(tables))
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		name := unquote(tables[i].Name())
+		for k, fk := range tables[i].ForeignKeys() {
+			ref := unquote(fk.ReferenceTableName())
+			if ref == name {
+				continue
+			}
+			for _, j := range byName[ref] {
+				switch state[j] {
+				case unvisited:
+					visit(j)
+				case visiting:
+					if cyclic[i] == nil {
+						cyclic[i] = make(map[int]bool)
+					}
+					cyclic[i][k] = true
+				}
+			}
+		}
+		state[i] = visited
+		order = append(order, i)
+	}
+	for i := range tables {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+
+	sorted := make([]dialect.Table, 0, len(tables))
+	var deferred []deferredForeignKey
+	for _, i := range order {
+		t := tables[i]
+		if cyclic[i] == nil {
+			sorted = append(sorted, t)
+			continue
+		}
+		var kept dialect.ForeignKeys
+		for k, fk := range t.ForeignKeys() {
+			sql := ""
+			if cyclic[i][k] {
+				sql = addForeignKeySQL(d, t.Name(), fk.ToSQL())
+			}
+			if sql == "" {
+				kept = append(kept, fk)
+				continue
+			}
+			deferred = append(deferred, deferredForeignKey{table: unquote(t.Name()), sql: sql})
+		}
+		if len(kept) != len(t.ForeignKeys()) {
+			t = cyclicTable{Table: t, foreignKeys: kept}
+		}
+		sorted = append(sorted, t)
+	}
+	return sorted, deferred
+}
+
+// addForeignKeySQL returns ALTER TABLE statement that adds the foreign key to the table,
+// or empty string if the dialect can not add it.
+func addForeignKeySQL(d dialect.Dialect, table, foreignKey string) string {
+	if m, ok := d.(dialect.Migrator); ok {
+		return m.AddForeignKeySQL(table, foreignKey)
+	}
+	return fmt.Sprintf("ALTER TABLE %s ADD %s;", table, foreignKey)
+}
+
+// foreignKeysSQL returns ALTER TABLE statements of the deferred foreign keys in a block
+// separated by a blank line, or empty string if there are no foreign keys.
+func foreignKeysSQL(fks []deferredForeignKey) string {
+	if len(fks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n")
+	for _, fk := range fks {
+		b.WriteString(fk.sql + "\n")
+	}
+	return b.String()
+}
+
+// unquote returns the name without quotes of the dialect.
+func unquote(name string) string {
+	return strings.Trim(name, "`\"[]")
+}
@@ -0,0 +1,159 @@
+package ddlmaker
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+type ArticleComment struct {
+	ID        uint64
+	ArticleID uint64
+}
+
+func (ArticleComment) PrimaryKey() dialect.PrimaryKey { return mysql.AddPrimaryKey("id") }
+
+func (ArticleComment) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{mysql.AddForeignKey([]string{"article_id"}, []string{"id"}, "article")}
+}
+
+type Article struct {
+	ID       uint64
+	TeamID   uint64
+	ParentID *uint64
+}
+
+func (Article) PrimaryKey() dialect.PrimaryKey { return mysql.AddPrimaryKey("id") }
+
+func (Article) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{
+		mysql.AddForeignKey([]string{"team_id"}, []string{"id"}, "team"),
+		mysql.AddForeignKey([]string{"parent_id"}, []string{"id"}, "article"),
+	}
+}
+
+type Team struct {
+	ID        uint64
+	LeaderID  uint64
+	ArticleID uint64
+}
+
+func (Team) PrimaryKey() dialect.PrimaryKey { return mysql.AddPrimaryKey("id") }
+
+func (Team) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{
+		mysql.AddForeignKey([]string{"leader_id"}, []string{"id"}, "leader"),
+		mysql.AddForeignKey([]string{"article_id"}, []string{"id"}, "article"),
+	}
+}
+
+type Node struct {
+	ID     int64
+	EdgeID int64
+}
+
+func (Node) PrimaryKey() dialect.PrimaryKey { return sqlite.AddPrimaryKey("id") }
+
+func (Node) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{sqlite.AddForeignKey([]string{"edge_id"}, []string{"id"}, "edge")}
+}
+
+type Edge struct {
+	ID     int64
+	NodeID int64
+}
+
+func (Edge) PrimaryKey() dialect.PrimaryKey { return sqlite.AddPrimaryKey("id") }
+
+func (Edge) ForeignKeys() dialect.ForeignKeys {
+	return dialect.ForeignKeys{sqlite.AddForeignKey([]string{"node_id"}, []string{"id"}, "node")}
+}
+
+func TestDDLMaker_sortTables(t *testing.T) {
+	tests := []struct {
+		name         string
+		driver       string
+		structs      []interface{}
+		wantTables   []string
+		wantDeferred []deferredForeignKey
+	}{
+		{
+			name:       "[Normal] referenced tables are created first",
+			driver:     "mysql",
+			structs:    []interface{}{ArticleComment{}, Article{}},
+			wantTables: []string{"article", "article_comment"},
+		},
+		{
+			name:       "[Normal] cycle is added after tables are created",
+			driver:     "mysql",
+			structs:    []interface{}{ArticleComment{}, Article{}, Team{}},
+			wantTables: []string{"team", "article", "article_comment"},
+			wantDeferred: []deferredForeignKey{
+				{table: "team", sql: "ALTER TABLE `team` ADD FOREIGN KEY (`article_id`) REFERENCES `article` (`id`);"},
+			},
+		},
+		{
+			name:       "[Normal] cycle is kept in CREATE TABLE if the dialect can not add foreign keys",
+			driver:     "sqlite",
+			structs:    []interface{}{Node{}, Edge{}},
+			wantTables: []string{"edge", "node"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm, err := New(Config{DB: DBConfig{Driver: tt.driver}, Logger: &recordLogger{}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := dm.AddStruct(tt.structs...); err != nil {
+				t.Fatal(err)
+			}
+			if err := dm.parse(); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, table := range dm.Tables {
+				got = append(got, unquote(table.Name()))
+			}
+			if diff := cmp.Diff(tt.wantTables, got); diff != "" {
+				t.Errorf("Compare tables is mismatch (-want +got):%s\n", diff)
+			}
+			if diff := cmp.Diff(tt.wantDeferred, dm.deferred, cmp.AllowUnexported(deferredForeignKey{})); diff != "" {
+				t.Errorf("Compare deferred foreign keys is mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+
+	t.Run("[Normal] foreign keys of cycle are added before footer", func(t *testing.T) {
+		dm, err := New(Config{DB: DBConfig{Driver: "mysql"}, Logger: &recordLogger{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.AddStruct(Article{}, Team{}); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		schema, err := dm.GenerateTo(context.Background(), &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{
+			"ALTER TABLE `team` ADD FOREIGN KEY (`article_id`) REFERENCES `article` (`id`);",
+			"SET foreign_key_checks=1;",
+		}
+		if diff := cmp.Diff(want, schema.Statements[len(schema.Statements)-2:]); diff != "" {
+			t.Errorf("Compare statements is mismatch (-want +got):%s\n", diff)
+		}
+		if strings.Count(buf.String(), "REFERENCES `article`") != 2 {
+			t.Errorf("foreign key of cycle is not moved out of CREATE TABLE:\n%s", buf.String())
+		}
+	})
+}
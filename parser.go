@@ -1,11 +1,15 @@
 package ddlmaker
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bournex/ordered_container"
 	"github.com/mnhkahn/ddl-maker/dialect"
@@ -33,6 +37,36 @@ type Index interface {
 	Indexes() dialect.Indexes
 }
 
+// Check is for type assertion
+type Check interface {
+	Checks() dialect.Checks
+}
+
+// TableOption is for type assertion.
+// TableOptions overrides engine and charset of Config for the table.
+type TableOption interface {
+	TableOptions() dialect.TableOptions
+}
+
+// DDLTyper is for type assertion. Types of fields implementing it declare
+// SQL type of the column for the driver (e.g. "mysql"). Empty string means
+// the type is resolved as usual.
+type DDLTyper interface {
+	DDLType(dialect string) string
+}
+
+// Enumer is for type assertion. Types of fields implementing it become
+// ENUM column (TEXT with CHECK constraint for dialects without ENUM) of the values.
+type Enumer interface {
+	EnumValues() []string
+}
+
+// Reference is for type assertion.
+// References returns the map of field name to the struct value that the field refers to.
+type Reference interface {
+	References() map[string]interface{}
+}
+
 func (dm *DDLMaker) parseJSON(data string) error {
 	m := ordered_container.OrderedMap{}
 	err := json.Unmarshal([]byte(data), &m)
@@ -75,6 +109,9 @@ func (dm *DDLMaker) parseJSON(data string) error {
 
 	table := newTable("foo", mysql.AddPrimaryKey("id"), nil, cols, idxs, dm.Dialect)
 	dm.Tables = append(dm.Tables, table)
+	// Tables are no longer the ones parsed from Structs.
+	dm.deferred = nil
+	dm.parsed = false
 
 	return nil
 }
@@ -97,34 +134,190 @@ func disambiguateFloatInt(value interface{}) string {
 	return reflect.TypeOf(value).Name()
 }
 
+// parse converts added structs into tables, and replaces Tables with them. It does not stop at the
+// first error, and returns *ParseError that has errors of all structs and fields. Tables are not
+// changed in that case.
 func (dm *DDLMaker) parse() error {
+	var docs *docComments
+	if dm.config.DocComments {
+		docs = newDocComments()
+	}
+
+	var tables []dialect.Table
+	var errs []error
 	for _, s := range dm.Structs {
 		val := reflect.Indirect(reflect.ValueOf(s))
 		rt := val.Type()
+		name := tableNameOf(s)
+		if !dm.config.Tables.selected(name) {
+			continue
+		}
+
+		// Table-level checks run on the valid columns even if some fields are invalid,
+		// so that all errors of the struct are reported at once.
+		columns, fieldErrs := dm.parseFields(rt, "", docs)
+		errs = append(errs, fieldErrs...)
+		if err := checkDuplicateColumns(columns); err != nil {
+			errs = append(errs, &TableError{Struct: rt.Name(), Table: name, Err: err})
+			continue
+		}
+
+		t, err := dm.parseTable(s, columns)
+		if err != nil {
+			errs = append(errs, &TableError{Struct: rt.Name(), Table: name, Err: err})
+			continue
+		}
+		doc, err := docs.typeDoc(rt)
+		if err != nil {
+			errs = append(errs, &TableError{Struct: rt.Name(), Table: name, Err: err})
+			continue
+		}
+		if tbl, ok := t.(table); ok && doc != "" && tbl.options.Comment == "" {
+			tbl.options.Comment = doc
+			t = tbl
+		}
+		tables = append(tables, t)
+	}
+	if len(errs) > 0 {
+		return &ParseError{Errors: errs}
+	}
+
+	dm.Tables, dm.deferred = sortTables(tables, dm.Dialect)
+	dm.parsed = true
+	for _, fk := range dm.deferred {
+		dm.logger().Info("foreign key of cycle is added after tables are created", "table", fk.table)
+	}
+	return nil
+}
+
+// parseIfNeeded parses added structs unless they are parsed after the last AddStruct.
+func (dm *DDLMaker) parseIfNeeded() error {
+	if dm.parsed {
+		return nil
+	}
+	return dm.parse()
+}
 
-		var columns []dialect.Column
-		for i := 0; i < rt.NumField(); i++ {
-			rtField := rt.Field(i)
-			column, err := parseField(rtField, dm.Dialect)
-			if err != nil {
-				if err == ErrIgnoreField {
-					continue
+// parseFields converts fields of the struct into columns. Anonymous struct
+// fields are flattened recursively, and named struct fields that have
+// prefix=<prefix> tag are flattened with column names prefixed.
+// Column names are converted from field names by the naming strategy of the config.
+// Doc comments of the fields are used as comments of the columns if docs is not nil.
+// It returns *FieldError of every field that can not be converted, which has the name of rt and
+// the path of the embedded struct fields to the field.
+func (dm *DDLMaker) parseFields(rt reflect.Type, prefix string, docs *docComments) ([]dialect.Column, []error) {
+	var columns []dialect.Column
+	var errs []error
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if embedded, fieldPrefix, ok := embeddedStruct(field); ok {
+			cols, fieldErrs := dm.parseFields(embedded, prefix+fieldPrefix, docs)
+			columns = append(columns, cols...)
+			for _, err := range fieldErrs {
+				if fieldErr, ok := err.(*FieldError); ok {
+					fieldErr.Struct = rt.Name()
+					fieldErr.Path = strings.TrimSuffix(field.Name+"."+fieldErr.Path, ".")
 				}
-				return fmt.Errorf("error parse field: %w", err) // This pass will not go through.
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		c, err := dm.parseColumn(rt, field, prefix, docs)
+		if err != nil {
+			if err == ErrIgnoreField {
+				continue
 			}
-			columns = append(columns, column)
+			errs = append(errs, &FieldError{
+				Struct: rt.Name(),
+				Field:  field.Name,
+				Type:   field.Type.String(),
+				Tag:    field.Tag.Get(TAGPREFIX),
+				Err:    err,
+			})
+			continue
 		}
+		columns = append(columns, c)
+	}
+	return columns, errs
+}
 
-		table := parseTable(s, columns, dm.Dialect)
-		dm.Tables = append(dm.Tables, table)
+// parseColumn converts the field of the struct into the column, and validates its type and tags.
+func (dm *DDLMaker) parseColumn(rt reflect.Type, field reflect.StructField, prefix string, docs *docComments) (dialect.Column, error) {
+	c, err := parseField(field, dm.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	col, ok := c.(column)
+	if !ok {
+		return c, nil
+	}
+	name, err := dm.config.columnName(field.Name)
+	if err != nil {
+		return nil, err
+	}
+	col.name = prefix + name
+	col.types = dm.types
+	if col.doc, err = docs.fieldDoc(rt, field.Name); err != nil {
+		return nil, err
+	}
+	// Types and tags are validated here so that errors are reported with the field.
+	if _, err := col.ToSQL(); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// embeddedStruct returns the struct type to be flattened and the prefix of its columns.
+// Structs that are stored in a column (e.g. time.Time, sql.NullString) are not flattened.
+func embeddedStruct(field reflect.StructField) (reflect.Type, string, bool) {
+	tag := normalizeTag(field.Tag.Get(TAGPREFIX))
+	prefix, hasPrefix := "", false
+	for _, elem := range splitTag(tag) {
+		if elem == IGNORETAG {
+			return nil, "", false
+		}
+		if strings.HasPrefix(elem, "prefix=") {
+			prefix, hasPrefix = strings.TrimPrefix(elem, "prefix="), true
+		}
+	}
+	if !field.Anonymous && !hasPrefix {
+		return nil, "", false
+	}
+
+	rt := field.Type
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct || rt == timeType ||
+		rt.Implements(valuerType) || reflect.PtrTo(rt).Implements(valuerType) {
+		return nil, "", false
+	}
+	return rt, prefix, true
+}
+
+// checkDuplicateColumns returns error if columns have the same name
+// (e.g. the field of embedded struct conflicts with the field of the parent).
+func checkDuplicateColumns(columns []dialect.Column) error {
+	names := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		if _, ok := names[c.Name()]; ok {
+			return fmt.Errorf("%w: %s", ErrDuplicateColumn, c.Name())
+		}
+		names[c.Name()] = struct{}{}
 	}
 	return nil
 }
 
 func parseField(field reflect.StructField, d dialect.Dialect) (dialect.Column, error) {
-	tagStr := strings.Replace(field.Tag.Get(TAGPREFIX), " ", "", -1)
+	tagStr := normalizeTag(field.Tag.Get(TAGPREFIX))
 
-	for _, tag := range strings.Split(tagStr, ",") {
+	for _, tag := range splitTag(tagStr) {
 		if tag == IGNORETAG {
 			return nil, ErrIgnoreField
 		}
@@ -150,30 +343,458 @@ func parseField(field reflect.StructField, d dialect.Dialect) (dialect.Column, e
 		typeName = field.Type.Name()
 	}
 
-	return newColumn(nameconv.ToSnakeCase(field.Name), typeName, tagStr, d), nil
+	c := newColumn(nameconv.ToSnakeCase(field.Name), typeName, tagStr, d)
+	c.goType, c.kind = namedType(field.Type)
+	c.typer, c.valueType = inspectValuer(field.Type)
+	c.enumValues = enumValues(field.Type)
+	c.nullable = nullableType(field.Type)
+	return c, nil
 }
 
-func parseTable(s interface{}, columns []dialect.Column, d dialect.Dialect) dialect.Table {
-	var tableName string
+// nullableType reports whether the Go type can be NULL. It is true for pointers and
+// Null types of database/sql and the MySQL driver (e.g. sql.NullString, mysql.NullTime).
+func nullableType(rt reflect.Type) bool {
+	if rt.Kind() == reflect.Ptr {
+		return true
+	}
+	switch rt.PkgPath() {
+	case "database/sql", "github.com/go-sql-driver/mysql":
+		return strings.HasPrefix(rt.Name(), "Null")
+	}
+	return false
+}
+
+// inspectValuer returns DDLTyper implemented by the named type, and the Go type
+// of the value that Value() of the zero value returns if it implements driver.Valuer.
+func inspectValuer(rt reflect.Type) (DDLTyper, string) {
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.PkgPath() == "" || rt.Name() == "" {
+		return nil, ""
+	}
+
+	// the pointer has methods of both value and pointer receivers
+	v := reflect.New(rt).Interface()
+	typer, _ := v.(DDLTyper)
+	valueType := ""
+	if valuer, ok := v.(driver.Valuer); ok {
+		valueType = driverValueType(valuer)
+	}
+	return typer, valueType
+}
+
+// enumValues returns EnumValues() of the named type if it implements Enumer.
+func enumValues(rt reflect.Type) []string {
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if enumer, ok := reflect.New(rt).Interface().(Enumer); ok {
+		return enumer.EnumValues()
+	}
+	return nil
+}
+
+// driverValueType returns the Go type of driver.Value returned by the valuer.
+// It returns empty string if the type can not be inferred (e.g. Value() returns nil).
+func driverValueType(valuer driver.Valuer) (typeName string) {
+	defer func() {
+		// Value() of the zero value may panic
+		if recover() != nil {
+			typeName = ""
+		}
+	}()
+
+	value, err := valuer.Value()
+	if err != nil {
+		return ""
+	}
+	switch value.(type) {
+	case int64:
+		return "int64"
+	case float64:
+		return "float64"
+	case bool:
+		return "bool"
+	case []byte:
+		return "[]uint8"
+	case string:
+		return "string"
+	case time.Time:
+		return "time.Time"
+	}
+	return ""
+}
+
+// namedType returns fully qualified name and underlying kind of the named type.
+// The pointer is removed from the name, and kept in the kind (e.g. *uint64).
+func namedType(rt reflect.Type) (string, string) {
+	pointer := ""
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+		pointer = "*"
+	}
+	if rt.PkgPath() == "" || rt.Name() == "" {
+		return "", ""
+	}
+
+	name := rt.PkgPath() + "." + rt.Name()
+	switch rt.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return name, pointer + rt.Kind().String()
+	case reflect.Int:
+		return name, pointer + "int64"
+	case reflect.Uint:
+		return name, pointer + "uint64"
+	case reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return name, "[]uint8"
+		}
+	}
+	return name, ""
+}
+
+func (dm *DDLMaker) parseTable(s interface{}, columns []dialect.Column) (dialect.Table, error) {
+	d := dm.Dialect
 	var primaryKey dialect.PrimaryKey
 	var foreignKeys dialect.ForeignKeys
 	var indexes dialect.Indexes
+	var checks dialect.Checks
 
-	if v, ok := s.(Table); ok {
-		tableName = nameconv.ToSnakeCase(v.Table())
-	} else {
-		val := reflect.Indirect(reflect.ValueOf(s))
-		tableName = nameconv.ToSnakeCase(val.Type().Name())
+	tableName := tableNameOf(s)
+
+	keys, err := parseKeyTags(columns)
+	if err != nil {
+		return nil, fmt.Errorf("error table %s: %w", tableName, err)
+	}
+	if v, ok := s.(Reference); ok {
+		if err := dm.resolveReferences(&keys, v.References()); err != nil {
+			return nil, fmt.Errorf("error table %s: %w", tableName, err)
+		}
 	}
+	if !keys.empty() {
+		b, err := dialect.NewBuilder(d)
+		if err != nil {
+			return nil, fmt.Errorf("error table %s: %w", tableName, err)
+		}
+		if len(keys.primaryKey) > 0 {
+			primaryKey = b.PrimaryKey(keys.primaryKey.columns())
+		}
+		for _, idx := range keys.indexes {
+			if idx.unique {
+				indexes = append(indexes, b.UniqueIndex(idx.name, tableName, idx.columns.columns()))
+			} else {
+				indexes = append(indexes, b.Index(idx.name, tableName, idx.columns.columns()))
+			}
+		}
+		for _, fk := range keys.foreignKeys {
+			if fk.refTable == "" {
+				return nil, fmt.Errorf("%w: referential action of %s is set without fk tag", ErrInvalidTag, fk.column)
+			}
+			foreignKeys = append(foreignKeys, b.ForeignKey("",
+				[]string{fk.column}, []string{fk.refColumn}, fk.refTable, fk.onDelete, fk.onUpdate))
+		}
+		for _, c := range keys.checks {
+			checks = append(checks, b.Check(checkName(tableName, c.column), c.expression))
+		}
+	}
+
 	if v, ok := s.(PrimaryKey); ok {
+		if primaryKey != nil {
+			return nil, fmt.Errorf("%w: table %s", ErrDuplicatePrimaryKey, tableName)
+		}
 		primaryKey = v.PrimaryKey()
 	}
 	if v, ok := s.(ForeignKey); ok {
-		foreignKeys = v.ForeignKeys()
+		foreignKeys = append(foreignKeys, v.ForeignKeys()...)
 	}
 	if v, ok := s.(Index); ok {
-		indexes = v.Indexes()
+		for _, idx := range v.Indexes() {
+			if keys.hasIndex(idx.Name()) {
+				return nil, fmt.Errorf("%w: %s of table %s", ErrDuplicateIndex, idx.Name(), tableName)
+			}
+			indexes = append(indexes, idx)
+		}
+	}
+	if v, ok := s.(Check); ok {
+		checks = append(checks, v.Checks()...)
+	}
+	names := make(map[string]struct{}, len(checks))
+	for _, c := range checks {
+		if _, ok := names[c.Name()]; ok {
+			return nil, fmt.Errorf("%w: %s of table %s", ErrDuplicateCheck, c.Name(), tableName)
+		}
+		names[c.Name()] = struct{}{}
+	}
+
+	t := newTable(tableName, primaryKey, foreignKeys, columns, indexes, d)
+	t.checks = checks
+	if v, ok := s.(TableOption); ok {
+		t.options = v.TableOptions()
+	}
+	return t, nil
+}
+
+// checkName returns the name of check constraint declared by check tag of the column.
+func checkName(table, column string) string {
+	return fmt.Sprintf("%s_%s_check", table, column)
+}
+
+// tableNameOf returns table name of the struct. It is Table() if the struct has the method.
+func tableNameOf(s interface{}) string {
+	if v, ok := s.(Table); ok {
+		return nameconv.ToSnakeCase(v.Table())
+	}
+	val := reflect.Indirect(reflect.ValueOf(s))
+	return nameconv.ToSnakeCase(val.Type().Name())
+}
+
+// primaryKeyColumnsOf returns primary key columns of the struct referred by References.
+// They are resolved in the same way as the table of the struct, by pk tags of the fields
+// (including fields of embedded structs) or PrimaryKey(). It returns error if the primary key
+// is not declared or declared twice.
+func (dm *DDLMaker) primaryKeyColumnsOf(s interface{}) ([]string, error) {
+	rt := reflect.Indirect(reflect.ValueOf(s)).Type()
+	columns, errs := dm.parseFields(rt, "", nil)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	keys, err := parseKeyTags(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	pk := keys.primaryKey.columns()
+	if v, ok := s.(PrimaryKey); ok {
+		if len(pk) > 0 {
+			return nil, fmt.Errorf("%w: table %s", ErrDuplicatePrimaryKey, tableNameOf(s))
+		}
+		if v.PrimaryKey() != nil {
+			for _, c := range v.PrimaryKey().Columns() {
+				pk = append(pk, strings.Trim(c, "`\"[]"))
+			}
+		}
+	}
+	if len(pk) == 0 {
+		return nil, fmt.Errorf("%w: table %s", ErrNoPrimaryKey, tableNameOf(s))
+	}
+	return pk, nil
+}
+
+// keyTags is primary key, indexes, foreign keys and check constraints declared by ddl tags of fields.
+type keyTags struct {
+	primaryKey  keyColumns
+	indexes     []*indexTag
+	foreignKeys []*foreignKeyTag
+	checks      []checkTag
+}
+
+// checkTag is the check constraint declared by check=expression tag.
+type checkTag struct {
+	column     string
+	expression string
+}
+
+// foreignKeyTag is the foreign key declared by fk=table.column, ondelete=action
+// and onupdate=action tags. refTable is empty until it is resolved by References().
+type foreignKeyTag struct {
+	column    string
+	refTable  string
+	refColumn string
+	onDelete  string
+	onUpdate  string
+}
+
+// indexTag is the index declared by index=name or unique=name tags.
+type indexTag struct {
+	name    string
+	unique  bool
+	columns keyColumns
+}
+
+// keyColumn is the column of key with the order specified by the suffix (e.g. index=idx_a:2).
+type keyColumn struct {
+	name  string
+	order int
+}
+
+type keyColumns []keyColumn
+
+// columns returns column names sorted by the order. Columns without
+// order keep the order of fields.
+func (kc keyColumns) columns() []string {
+	sorted := make(keyColumns, len(kc))
+	copy(sorted, kc)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].order < sorted[j].order
+	})
+	columns := make([]string, 0, len(sorted))
+	for _, c := range sorted {
+		columns = append(columns, c.name)
+	}
+	return columns
+}
+
+func (k keyTags) empty() bool {
+	return len(k.primaryKey) == 0 && len(k.indexes) == 0 && len(k.foreignKeys) == 0 && len(k.checks) == 0
+}
+
+// resolveReferences sets referenced table and column of the fields in refs to the keys.
+// The table name is resolved by Table() of the referenced struct, and the column names
+// are converted from the field names by the naming strategy of the config.
+func (dm *DDLMaker) resolveReferences(k *keyTags, refs map[string]interface{}) error {
+	fields := make([]string, 0, len(refs))
+	for field := range refs {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		ref := refs[field]
+		if ref == nil || reflect.Indirect(reflect.ValueOf(ref)).Kind() != reflect.Struct {
+			return fmt.Errorf("%w: reference of %s is not a struct", ErrInvalidTag, field)
+		}
+		refColumns, err := dm.primaryKeyColumnsOf(ref)
+		if err != nil {
+			return fmt.Errorf("error reference of %s: %w", field, err)
+		}
+		if len(refColumns) != 1 {
+			return fmt.Errorf("%w: %s refers to composite primary key", ErrInvalidTag, field)
+		}
+
+		column, err := dm.config.columnName(field)
+		if err != nil {
+			return err
+		}
+		var fk *foreignKeyTag
+		for _, v := range k.foreignKeys {
+			if v.column == column {
+				fk = v
+			}
+		}
+		switch {
+		case fk == nil:
+			fk = &foreignKeyTag{column: column}
+			k.foreignKeys = append(k.foreignKeys, fk)
+		case fk.refTable != "":
+			return fmt.Errorf("%w: %s is declared by both fk tag and References", ErrInvalidTag, field)
+		}
+		fk.refTable = tableNameOf(ref)
+		fk.refColumn = refColumns[0]
+	}
+	return nil
+}
+
+func (k keyTags) hasIndex(name string) bool {
+	for _, idx := range k.indexes {
+		if idx.name == strings.Trim(name, "`\"[]") {
+			return true
+		}
 	}
+	return false
+}
 
-	return newTable(tableName, primaryKey, foreignKeys, columns, indexes, d)
+// parseKeyTags collects pk, index=name, unique=name, fk and check tags of the columns.
+// Fields that share the index name make the composite index.
+func parseKeyTags(columns []dialect.Column) (keyTags, error) {
+	var keys keyTags
+	indexes := make(map[string]*indexTag)
+	for _, c := range columns {
+		col, ok := c.(column)
+		if !ok {
+			continue
+		}
+		var fk *foreignKeyTag
+		foreignKey := func() *foreignKeyTag {
+			if fk == nil {
+				fk = &foreignKeyTag{column: col.name}
+				keys.foreignKeys = append(keys.foreignKeys, fk)
+			}
+			return fk
+		}
+		for _, elem := range splitTag(col.tag) {
+			key, value, _ := strings.Cut(elem, "=")
+			switch key {
+			case "fk":
+				table, refColumn, ok := strings.Cut(value, ".")
+				if !ok || table == "" || refColumn == "" {
+					return keyTags{}, fmt.Errorf("%w: fk tag of %s must be table.column", ErrInvalidTag, col.name)
+				}
+				foreignKey().refTable = table
+				foreignKey().refColumn = refColumn
+			case "ondelete", "onupdate":
+				action, err := referentialAction(value)
+				if err != nil {
+					return keyTags{}, fmt.Errorf("error %s tag of %s: %w", key, col.name, err)
+				}
+				if key == "ondelete" {
+					foreignKey().onDelete = action
+				} else {
+					foreignKey().onUpdate = action
+				}
+			case "check":
+				if value == "" {
+					return keyTags{}, fmt.Errorf("%w: check tag of %s", ErrInvalidTag, col.name)
+				}
+				keys.checks = append(keys.checks, checkTag{column: col.name, expression: value})
+			case "pk":
+				order, err := keyOrder(value)
+				if err != nil {
+					return keyTags{}, fmt.Errorf("error pk tag of %s: %w", col.name, err)
+				}
+				keys.primaryKey = append(keys.primaryKey, keyColumn{name: col.name, order: order})
+			case "index", "unique":
+				name, suffix, _ := strings.Cut(value, ":")
+				if name == "" {
+					return keyTags{}, fmt.Errorf("%w: %s tag of %s", ErrInvalidTag, key, col.name)
+				}
+				order, err := keyOrder(suffix)
+				if err != nil {
+					return keyTags{}, fmt.Errorf("error %s tag of %s: %w", key, col.name, err)
+				}
+				idx, ok := indexes[name]
+				if !ok {
+					idx = &indexTag{name: name, unique: key == "unique"}
+					indexes[name] = idx
+					keys.indexes = append(keys.indexes, idx)
+				}
+				if idx.unique != (key == "unique") {
+					return keyTags{}, fmt.Errorf("%w: %s is declared as both index and unique", ErrDuplicateIndex, name)
+				}
+				idx.columns = append(idx.columns, keyColumn{name: col.name, order: order})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// referentialAction converts the value of ondelete and onupdate tags into SQL.
+// Underscores and spaces are ignored, so set_null, setnull and set null mean SET NULL.
+func referentialAction(s string) (string, error) {
+	switch strings.NewReplacer("_", "", " ", "").Replace(strings.ToLower(s)) {
+	case "cascade":
+		return "CASCADE", nil
+	case "setnull":
+		return "SET NULL", nil
+	case "setdefault":
+		return "SET DEFAULT", nil
+	case "restrict":
+		return "RESTRICT", nil
+	case "noaction":
+		return "NO ACTION", nil
+	}
+	return "", fmt.Errorf("%w: referential action %q", ErrInvalidTag, s)
+}
+
+func keyOrder(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	order, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: order %q is not a number", ErrInvalidTag, s)
+	}
+	return order, nil
 }
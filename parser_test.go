@@ -1,14 +1,22 @@
 package ddlmaker
 
 import (
+	"bytes"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"text/template"
 	"time"
 
-	"github.com/nao1215/ddl-maker/dialect"
-	"github.com/nao1215/ddl-maker/dialect/mock"
-	"github.com/nao1215/ddl-maker/dialect/mysql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mock"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/postgres"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
 )
 
 type T1 struct {
@@ -48,6 +56,31 @@ func (t1 T1) ForeignKeys() dialect.ForeignKeys {
 	}
 }
 
+// parseStruct adds s to dm, parses it by DDLMaker.parse and returns the table of s.
+func parseStruct(t *testing.T, dm *DDLMaker, s interface{}) (dialect.Table, error) {
+	t.Helper()
+	if err := dm.AddStruct(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.parse(); err != nil {
+		return nil, err
+	}
+	return dm.Tables[0], nil
+}
+
+// columnsSQL returns definitions of the columns of the table.
+func columnsSQL(table dialect.Table) ([]string, error) {
+	var got []string
+	for _, c := range table.Columns() {
+		sql, err := c.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		got = append(got, sql)
+	}
+	return got, nil
+}
+
 func TestParseField(t *testing.T) {
 	t1 := T1{}
 	idColumn := column{
@@ -64,12 +97,15 @@ func TestParseField(t *testing.T) {
 	descColumn := column{
 		name:     "description",
 		typeName: "sql.NullString",
+		goType:   "database/sql.NullString",
 		tag:      "null,text",
+		nullable: true,
 		dialect:  mysql.MySQL{},
 	}
 	createdAtColumn := column{
 		name:     "created_at",
 		typeName: "time.Time",
+		goType:   "time.Time",
 		dialect:  mysql.MySQL{},
 	}
 	binaryColumn := column{
@@ -105,7 +141,10 @@ func TestParseTable(t *testing.T) {
 	d := mysql.MySQL{}
 
 	var columns []dialect.Column
-	table := parseTable(t1, columns, d)
+	table, err := (&DDLMaker{Dialect: d}).parseTable(t1, columns)
+	if err != nil {
+		t.Fatal(err)
+	}
 	if table.Name() != d.Quote(t1.Table()) {
 		t.Fatal("error parse table name", table.Name())
 	}
@@ -123,21 +162,718 @@ func TestParseTable(t *testing.T) {
 	}
 }
 
+type T3 struct {
+	ID        uint64 `ddl:"pk"`
+	UserID    uint64 `ddl:"unique=uniq_user_entry:2,index=idx_user"`
+	EntryID   uint64 `ddl:"unique=uniq_user_entry:1"`
+	Title     string `ddl:"index=idx_title"`
+	CreatedAt time.Time
+}
+
+func (t3 T3) Indexes() dialect.Indexes {
+	return dialect.Indexes{
+		mysql.AddIndex("created_at_idx", "created_at"),
+	}
+}
+
+type T4 struct {
+	ID uint64 `ddl:"pk"`
+}
+
+func (t4 T4) PrimaryKey() dialect.PrimaryKey {
+	return mysql.AddPrimaryKey("id")
+}
+
+type T5 struct {
+	ID    uint64 `ddl:"pk=2"`
+	Title string `ddl:"index=created_at_idx"`
+	Kind  string `ddl:"pk=1,index=kind_idx:x"`
+}
+
+func (t5 T5) Indexes() dialect.Indexes {
+	return dialect.Indexes{
+		mysql.AddIndex("created_at_idx", "created_at"),
+	}
+}
+
+func TestParseTable_KeyTags(t *testing.T) {
+	t.Run("[Normal] MySQL keys are merged with Indexes method", func(t *testing.T) {
+		d := mysql.MySQL{}
+		table, err := parseStruct(t, &DDLMaker{Dialect: d}, T3{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := table.PrimaryKey().ToSQL(); got != "PRIMARY KEY (`id`)" {
+			t.Errorf("mismatch primary key: %s", got)
+		}
+		var got []string
+		for _, idx := range table.Indexes() {
+			got = append(got, idx.ToSQL())
+		}
+		want := []string{
+			"UNIQUE `uniq_user_entry` (`entry_id`, `user_id`)",
+			"INDEX `idx_user` (`user_id`)",
+			"INDEX `idx_title` (`title`)",
+			"INDEX `created_at_idx` (`created_at`)",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] SQLite keys", func(t *testing.T) {
+		d := sqlite.SQLite{}
+		type Tag struct {
+			ID   uint64 `ddl:"pk"`
+			Name string `ddl:"unique=tag_name_uniq"`
+		}
+		table, err := parseStruct(t, &DDLMaker{Dialect: d}, Tag{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := table.Indexes()[0].ToSQL(); got != "CREATE UNIQUE INDEX `tag_name_uniq` ON `tag` (`name`);" {
+			t.Errorf("mismatch index: %s", got)
+		}
+	})
+
+	t.Run("[Error] primary key is declared twice", func(t *testing.T) {
+		d := mysql.MySQL{}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, T4{})
+		if !errors.Is(err, ErrDuplicatePrimaryKey) {
+			t.Errorf("mismatch want=%v, got=%v", ErrDuplicatePrimaryKey, err)
+		}
+	})
+
+	t.Run("[Error] invalid order suffix", func(t *testing.T) {
+		d := mysql.MySQL{}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, T5{})
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("mismatch want=%v, got=%v", ErrInvalidTag, err)
+		}
+	})
+
+	t.Run("[Error] dialect does not support keys", func(t *testing.T) {
+		d := mock.SQLMock{}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, T4{})
+		if !errors.Is(err, dialect.ErrBuilderNotSupported) {
+			t.Errorf("mismatch want=%v, got=%v", dialect.ErrBuilderNotSupported, err)
+		}
+	})
+}
+
+type T7 struct {
+	ID    uint64 `ddl:"pk"`
+	Price int64  `ddl:"check=price >= 0"`
+	Stock int64  `ddl:"check = stock BETWEEN 0 AND 100"`
+	Sale  int64
+}
+
+func (t7 T7) Checks() dialect.Checks {
+	return dialect.Checks{
+		mysql.AddCheck("sale_lt_price", "sale < price"),
+	}
+}
+
+type T8 struct {
+	Price int64 `ddl:"check=price >= 0"`
+}
+
+func (t8 T8) Checks() dialect.Checks {
+	return dialect.Checks{
+		mysql.AddCheck("t_8_price_check", "price > 0"),
+	}
+}
+
+func TestParseTable_CheckTags(t *testing.T) {
+	t.Run("[Normal] check tags are merged with Checks method", func(t *testing.T) {
+		d := mysql.MySQL{}
+		table, err := parseStruct(t, &DDLMaker{Dialect: d}, T7{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, c := range table.Checks() {
+			got = append(got, c.ToSQL())
+		}
+		want := []string{
+			"CONSTRAINT `t_7_price_check` CHECK (price >= 0)",
+			"CONSTRAINT `t_7_stock_check` CHECK (stock BETWEEN 0 AND 100)",
+			"CONSTRAINT `sale_lt_price` CHECK (sale < price)",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+
+		var ddl bytes.Buffer
+		tmpl := template.Must(template.New("table").Parse(d.TableTemplate()))
+		if err := tmpl.Execute(&ddl, table); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(ddl.String(), "    CONSTRAINT `sale_lt_price` CHECK (sale < price),\n    PRIMARY KEY (`id`)\n") {
+			t.Errorf("check constraints are not rendered: %s", ddl.String())
+		}
+	})
+
+	t.Run("[Normal] SQLite", func(t *testing.T) {
+		d := sqlite.SQLite{}
+		type Stock struct {
+			Count int64 `ddl:"check=count >= 0"`
+		}
+		table, err := parseStruct(t, &DDLMaker{Dialect: d}, Stock{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := table.Checks()[0].ToSQL(); got != "CONSTRAINT `stock_count_check` CHECK (count >= 0)" {
+			t.Errorf("mismatch check: %s", got)
+		}
+	})
+
+	t.Run("[Error] check constraint is declared twice", func(t *testing.T) {
+		d := mysql.MySQL{}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, T8{})
+		if !errors.Is(err, ErrDuplicateCheck) {
+			t.Errorf("mismatch want=%v, got=%v", ErrDuplicateCheck, err)
+		}
+	})
+}
+
+type T9 struct {
+	ID uint64 `ddl:"pk"`
+}
+
+func (t9 T9) TableOptions() dialect.TableOptions {
+	return dialect.TableOptions{Comment: "options", Engine: "MyISAM"}
+}
+
+func TestParseTable_TableOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		d    dialect.Dialect
+		want string
+	}{
+		{
+			name: "[Normal] MySQL",
+			d:    mysql.MySQL{Engine: "InnoDB", Charset: "utf8mb4"},
+			want: ") ENGINE=MyISAM DEFAULT CHARACTER SET utf8mb4 COMMENT='options';",
+		},
+		{
+			name: "[Normal] PostgreSQL",
+			d:    postgres.PostgreSQL{},
+			want: `COMMENT ON TABLE "t_9" IS 'options';`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := parseStruct(t, &DDLMaker{Dialect: tt.d}, T9{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ddl bytes.Buffer
+			tmpl := template.Must(template.New("table").Parse(tt.d.TableTemplate()))
+			if err := tmpl.Execute(&ddl, table); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(ddl.String(), tt.want) {
+				t.Errorf("%s is not rendered: %s", tt.want, ddl.String())
+			}
+		})
+	}
+}
+
+type T6 struct {
+	ID       uint64 `ddl:"pk"`
+	PlayerID uint64 `ddl:"fk=player.id,ondelete=cascade"`
+	EntryID  uint64 `ddl:"ondelete=set_null,onupdate=cascade"`
+	TagID    uint64
+}
+
+func (t6 T6) References() map[string]interface{} {
+	return map[string]interface{}{
+		"EntryID": &T6Entry{},
+		"TagID":   T6Tag{},
+	}
+}
+
+type T6Key struct {
+	ID uint64 `ddl:"pk"`
+}
+
+type T6Entry struct {
+	T6Key
+	Title string
+}
+
+type T6Tag struct {
+	Code string
+}
+
+func (T6Tag) PrimaryKey() dialect.PrimaryKey {
+	return mysql.AddPrimaryKey("code")
+}
+
+func TestParseTable_ForeignKeyTags(t *testing.T) {
+	t.Run("[Normal] fk tag and References", func(t *testing.T) {
+		d := mysql.MySQL{}
+		table, err := parseStruct(t, &DDLMaker{Dialect: d}, T6{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, fk := range table.ForeignKeys() {
+			got = append(got, fk.ToSQL())
+		}
+		want := []string{
+			"FOREIGN KEY (`player_id`) REFERENCES `player` (`id`) ON DELETE CASCADE",
+			"FOREIGN KEY (`entry_id`) REFERENCES `t_6_entry` (`id`) ON DELETE SET NULL ON UPDATE CASCADE",
+			"FOREIGN KEY (`tag_id`) REFERENCES `t_6_tag` (`code`)",
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Error] referenced struct without primary key", func(t *testing.T) {
+		refs := map[string]interface{}{"PlayerID": T2{}}
+		err := (&DDLMaker{Dialect: mysql.MySQL{}}).resolveReferences(&keyTags{}, refs)
+		if !errors.Is(err, ErrNoPrimaryKey) {
+			t.Errorf("mismatch want=%v, got=%v", ErrNoPrimaryKey, err)
+		}
+	})
+
+	t.Run("[Error] referenced struct declares primary key twice", func(t *testing.T) {
+		refs := map[string]interface{}{"TagID": T4{}}
+		err := (&DDLMaker{Dialect: mysql.MySQL{}}).resolveReferences(&keyTags{}, refs)
+		if !errors.Is(err, ErrDuplicatePrimaryKey) {
+			t.Errorf("mismatch want=%v, got=%v", ErrDuplicatePrimaryKey, err)
+		}
+	})
+
+	t.Run("[Error] invalid fk tag", func(t *testing.T) {
+		d := sqlite.SQLite{}
+		type Entry struct {
+			PlayerID uint64 `ddl:"fk=player"`
+		}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, Entry{})
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("mismatch want=%v, got=%v", ErrInvalidTag, err)
+		}
+	})
+
+	t.Run("[Error] referential action without fk", func(t *testing.T) {
+		d := sqlite.SQLite{}
+		type Entry struct {
+			PlayerID uint64 `ddl:"ondelete=cascade"`
+		}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, Entry{})
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("mismatch want=%v, got=%v", ErrInvalidTag, err)
+		}
+	})
+
+	t.Run("[Error] unknown referential action", func(t *testing.T) {
+		d := sqlite.SQLite{}
+		type Entry struct {
+			PlayerID uint64 `ddl:"fk=player.id,ondelete=drop"`
+		}
+		_, err := parseStruct(t, &DDLMaker{Dialect: d}, Entry{})
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("mismatch want=%v, got=%v", ErrInvalidTag, err)
+		}
+	})
+}
+
 func TestDDLMaker_parse(t *testing.T) {
-	t.Run("[Normal] can parse ignore field and pointer field", func(t *testing.T) {
-		dm := DDLMaker{}
-		dm.Dialect = &mock.SQLMock{
-			Engine:  "dummy",
-			Charset: "dummy",
+	tests := []struct {
+		name         string
+		config       Config
+		d            dialect.Dialect
+		s            interface{}
+		want         []string
+		tableComment string
+		wantErr      error
+	}{
+		{
+			name: "[Normal] can parse ignore field and pointer field",
+			d:    mysql.MySQL{},
+			s:    &T2{},
+			want: []string{
+				"`id` BIGINT unsigned NOT NULL AUTO_INCREMENT COMMENT 'id'",
+				"`name` VARCHAR(191) NULL COMMENT 'name'",
+			},
+		},
+		{
+			name: "[Normal] MySQL uses DDLType of Valuer",
+			d:    mysql.MySQL{},
+			s:    &Order{},
+			want: []string{
+				"`id` BINARY(16) NOT NULL COMMENT 'id'",
+				"`status` VARCHAR(20) NOT NULL COMMENT 'status'",
+				"`note` VARCHAR(191) NULL COMMENT 'note'",
+			},
+		},
+		{
+			name: "[Normal] SQLite infers from Value of Valuer",
+			d:    sqlite.SQLite{},
+			s:    &Order{},
+			want: []string{
+				"`id` BLOB NOT NULL",
+				"`status` TEXT NOT NULL",
+				"`note` TEXT NULL",
+			},
+		},
+		{
+			name: "[Normal] MySQL enum",
+			d:    mysql.MySQL{},
+			s:    &Member{},
+			want: []string{
+				"`id` BIGINT unsigned NOT NULL COMMENT 'id'",
+				"`status` ENUM('active','banned','deleted') NOT NULL COMMENT 'status'",
+				"`previous` ENUM('active','banned','deleted') NULL COMMENT 'previous'",
+				"`role` ENUM('admin','member') NOT NULL COMMENT 'role'",
+			},
+		},
+		{
+			name: "[Normal] SQLite enum",
+			d:    sqlite.SQLite{},
+			s:    &Member{},
+			want: []string{
+				"`id` INTEGER NOT NULL",
+				"`status` TEXT CHECK (`status` IN ('active','banned','deleted')) NOT NULL",
+				"`previous` TEXT CHECK (`previous` IN ('active','banned','deleted')) NULL",
+				"`role` TEXT CHECK (`role` IN ('admin','member')) NOT NULL",
+			},
+		},
+		{
+			name:   "[Normal] doc comments of fields and type",
+			config: Config{DocComments: true},
+			d:      mysql.MySQL{},
+			s:      &Post{},
+			want: []string{
+				"`id` BIGINT unsigned NOT NULL COMMENT 'ID is the post''s identifier'",
+				"`title` VARCHAR(191) NOT NULL COMMENT 'headline'",
+				"`body` VARCHAR(191) NOT NULL COMMENT 'body'",
+				"`created_at` DATETIME NOT NULL COMMENT 'created_at'",
+				"`updated_at` DATETIME NOT NULL COMMENT 'updated_at'",
+			},
+			tableComment: `Post is the post written by "players".`,
+		},
+		{
+			name: "[Normal] column names without doc comments",
+			d:    mysql.MySQL{},
+			s:    &Post{},
+			want: []string{
+				"`id` BIGINT unsigned NOT NULL COMMENT 'id'",
+				"`title` VARCHAR(191) NOT NULL COMMENT 'headline'",
+				"`body` VARCHAR(191) NOT NULL COMMENT 'body'",
+				"`created_at` DATETIME NOT NULL COMMENT 'created_at'",
+				"`updated_at` DATETIME NOT NULL COMMENT 'updated_at'",
+			},
+		},
+		{
+			name:    "[Error] Value of zero value of Valuer panics",
+			d:       mysql.MySQL{},
+			s:       &Invoice{},
+			wantErr: mysql.ErrInvalidType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := parseStruct(t, &DDLMaker{Dialect: tt.d, config: tt.config}, tt.s)
+			var got []string
+			if err == nil {
+				got, err = columnsSQL(table)
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("mismatch want=%v, got=%v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+			}
+			if got := table.Options().Comment; got != tt.tableComment {
+				t.Errorf("mismatch table comment want=%s, got=%s", tt.tableComment, got)
+			}
+		})
+	}
+}
+
+type Timestamps struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Address struct {
+	City string
+	Zip  string `ddl:"size=10"`
+}
+
+type Base struct {
+	ID uint64 `ddl:"pk,auto"`
+	*Timestamps
+}
+
+type Customer struct {
+	Base
+	Name     string
+	Billing  Address `ddl:"prefix=billing_"`
+	Shipping Address `ddl:"prefix=shipping_"`
+	Deleted  sql.NullTime
+}
+
+type Conflict struct {
+	Timestamps
+	CreatedAt time.Time
+}
+
+func TestDDLMaker_parseEmbeddedStruct(t *testing.T) {
+	t.Run("[Normal] embedded structs are flattened", func(t *testing.T) {
+		dm := DDLMaker{Dialect: mysql.MySQL{}}
+		if err := dm.AddStruct(&Customer{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.parse(); err != nil {
+			t.Fatal(err)
 		}
 
-		if err := dm.AddStruct(&T2{}); err != nil {
+		var got []string
+		for _, c := range dm.Tables[0].Columns() {
+			got = append(got, c.Name())
+		}
+		want := []string{"id", "created_at", "updated_at", "name", "billing_city", "billing_zip", "shipping_city", "shipping_zip", "deleted"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+		if got := dm.Tables[0].PrimaryKey().ToSQL(); got != "PRIMARY KEY (`id`)" {
+			t.Errorf("mismatch primary key: %s", got)
+		}
+	})
+
+	t.Run("[Error] duplicate column name", func(t *testing.T) {
+		dm := DDLMaker{Dialect: mysql.MySQL{}}
+		if err := dm.AddStruct(&Conflict{}); err != nil {
 			t.Fatal(err)
 		}
+		if err := dm.parse(); !errors.Is(err, ErrDuplicateColumn) {
+			t.Errorf("mismatch want=%v, got=%v", ErrDuplicateColumn, err)
+		}
+	})
+}
+
+type UserID uint64
+
+type Money struct {
+	Amount int64
+}
+
+type Wallet struct {
+	ID      UserID
+	Owner   *UserID `ddl:"null"`
+	Balance Money
+	Limit   Money `ddl:"size=8"`
+}
 
-		got := dm.parse()
-		if got != nil {
-			t.Error(got)
+func TestDDLMaker_RegisterType(t *testing.T) {
+	toSQL := func(t *testing.T, dm *DDLMaker) []string {
+		t.Helper()
+		table, err := parseStruct(t, dm, &Wallet{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := columnsSQL(table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	t.Run("[Normal] types of DDLMaker take precedence over the dialect", func(t *testing.T) {
+		dialect.RegisterType("sqlite", "github.com/mnhkahn/ddl-maker.Money", dialect.Fixed("INTEGER"))
+		dialect.RegisterType("sqlite", "github.com/mnhkahn/ddl-maker.UserID", dialect.Fixed("BLOB"))
+		t.Cleanup(func() {
+			dialect.UnregisterType("sqlite", "github.com/mnhkahn/ddl-maker.Money")
+			dialect.UnregisterType("sqlite", "github.com/mnhkahn/ddl-maker.UserID")
+		})
+		dm := &DDLMaker{Dialect: sqlite.SQLite{}}
+		dm.RegisterType("github.com/mnhkahn/ddl-maker.UserID", dialect.Fixed("TEXT"))
+
+		want := []string{
+			"`id` TEXT NOT NULL",
+			"`owner` TEXT NULL",
+			"`balance` INTEGER NOT NULL",
+			"`limit` INTEGER NOT NULL",
+		}
+		if diff := cmp.Diff(want, toSQL(t, dm)); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] named type falls back to underlying kind", func(t *testing.T) {
+		dm := &DDLMaker{Dialect: mysql.MySQL{}}
+		dm.RegisterType("github.com/mnhkahn/ddl-maker.Money", dialect.Sized("DECIMAL(%d,2)", 12))
+
+		want := []string{
+			"`id` BIGINT unsigned NOT NULL COMMENT 'id'",
+			"`owner` BIGINT unsigned NULL COMMENT 'owner'",
+			"`balance` DECIMAL(12,2) NOT NULL COMMENT 'balance'",
+			"`limit` DECIMAL(8,2) NOT NULL COMMENT 'limit'",
+		}
+		if diff := cmp.Diff(want, toSQL(t, dm)); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
 		}
 	})
 }
+
+type Status struct {
+	name string
+}
+
+func (s Status) Value() (driver.Value, error) {
+	return s.name, nil
+}
+
+type Cents struct {
+	amount *int64
+}
+
+func (c *Cents) Value() (driver.Value, error) {
+	return *c.amount, nil // panics for zero value
+}
+
+type UUID [16]byte
+
+func (u UUID) Value() (driver.Value, error) {
+	return u[:], nil
+}
+
+func (u UUID) DDLType(dialect string) string {
+	if dialect == "mysql" {
+		return "BINARY(16)"
+	}
+	return ""
+}
+
+type Order struct {
+	ID     UUID
+	Status Status  `ddl:"size=20"`
+	Note   *Status `ddl:"null"`
+}
+
+type Invoice struct {
+	Total Cents
+}
+
+type AccountStatus string
+
+const (
+	AccountStatusActive  AccountStatus = "active"
+	AccountStatusBanned  AccountStatus = "banned"
+	AccountStatusDeleted AccountStatus = "deleted"
+)
+
+func (AccountStatus) EnumValues() []string {
+	return []string{string(AccountStatusActive), string(AccountStatusBanned), string(AccountStatusDeleted)}
+}
+
+type Member struct {
+	ID       uint64
+	Status   AccountStatus
+	Previous *AccountStatus `ddl:"null"`
+	Role     string         `ddl:"enum=admin|member"`
+}
+
+// Post is the post written by "players".
+type Post struct {
+	// ID is the post's identifier
+	ID uint64
+	// Title is overridden by comment tag
+	Title string `ddl:"comment=headline"`
+	Body  string
+	Timestamps
+}
+
+func TestParseDocComments(t *testing.T) {
+	got, err := parseDocComments("github.com/mnhkahn/ddl-maker/testdata/doc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"Note":         "Note is declared by the file selected by build constraints.",
+		"Note.Body":    "Body is the body of the note",
+		"Fixture":      "Fixture is declared only by the test file.",
+		"Fixture.Name": "Name is the name of the fixture",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+}
+
+type BrokenBase struct {
+	Flag bool `ddl:"null,notnull"`
+}
+
+type Broken struct {
+	BrokenBase
+	ID    uint64 `ddl:"pk"`
+	Total Cents
+	Code  string `ddl:"stored"`
+}
+
+func (Broken) PrimaryKey() dialect.PrimaryKey {
+	return mysql.AddPrimaryKey("id")
+}
+
+func TestDDLMaker_parseErrors(t *testing.T) {
+	dm, err := New(Config{DB: DBConfig{Driver: "mysql"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.AddStruct(&Broken{}, &Conflict{}, &Invoice{}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = dm.parse()
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("mismatch want=*ParseError, got=%v", err)
+	}
+	type location struct{ Struct, Path, Field, Type, Tag, Table string }
+	var got []location
+	for _, e := range parseErr.Errors {
+		var fieldErr *FieldError
+		var tableErr *TableError
+		switch {
+		case errors.As(e, &fieldErr):
+			got = append(got, location{Struct: fieldErr.Struct, Path: fieldErr.Path, Field: fieldErr.Field, Type: fieldErr.Type, Tag: fieldErr.Tag})
+		case errors.As(e, &tableErr):
+			got = append(got, location{Struct: tableErr.Struct, Table: tableErr.Table})
+		default:
+			t.Errorf("unexpected error type %T", e)
+		}
+	}
+	want := []location{
+		{Struct: "Broken", Path: "BrokenBase", Field: "Flag", Type: "bool", Tag: "null,notnull"},
+		{Struct: "Broken", Field: "Total", Type: "ddlmaker.Cents"},
+		{Struct: "Broken", Field: "Code", Type: "string", Tag: "stored"},
+		{Struct: "Broken", Table: "broken"},
+		{Struct: "Conflict", Table: "conflict"},
+		{Struct: "Invoice", Field: "Total", Type: "ddlmaker.Cents"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+
+	for _, target := range []error{ErrInvalidTag, ErrDuplicatePrimaryKey, ErrDuplicateColumn, mysql.ErrInvalidType} {
+		if !errors.Is(err, target) {
+			t.Errorf("errors.Is(err, %v) is false", target)
+		}
+	}
+	if len(dm.Tables) != 0 {
+		t.Errorf("tables are added: %d", len(dm.Tables))
+	}
+}
@@ -1,8 +1,96 @@
 package query
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
-// Quote encloses the string with ``.
+// Quote encloses the string with backquotes.
 func Quote(s string) string {
 	return fmt.Sprintf("`%s`", s)
 }
+
+// DoubleQuote encloses the string with "".
+func DoubleQuote(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+// QuoteLiteral encloses the string with single quotes and escapes the ones in it.
+func QuoteLiteral(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+// QuoteLiterals encloses each string with single quotes and joins them with commas.
+func QuoteLiterals(ss []string) string {
+	quoted := make([]string, 0, len(ss))
+	for _, s := range ss {
+		quoted = append(quoted, QuoteLiteral(s))
+	}
+	return strings.Join(quoted, ",")
+}
+
+// Bracket encloses the string with [].
+func Bracket(s string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(s, "]", "]]"))
+}
+
+// SplitStatements splits SQL into statements terminated by semicolons, which are
+// kept in the statements. Semicolons in quotes are not terminators. Line comments
+// are removed, and lines of GO (batch separator of SQL Server) terminate the statement as well.
+func SplitStatements(sql string) []string {
+	var stmts []string
+	var b strings.Builder
+	flush := func() {
+		if stmt := strings.TrimSpace(b.String()); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		b.Reset()
+	}
+
+	lineStart := true
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		if lineStart {
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			if strings.EqualFold(strings.TrimSpace(sql[i:i+end]), "GO") {
+				flush()
+				i += end
+				continue
+			}
+		}
+		lineStart = c == '\n'
+
+		switch {
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			i += end - 1
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closer := c
+			if c == '[' {
+				closer = ']'
+			}
+			end := strings.IndexByte(sql[i+1:], closer)
+			if end < 0 {
+				b.WriteString(sql[i:])
+				i = len(sql)
+				continue
+			}
+			// doubled closing character is an escaped one, and it is read as two quotes
+			b.WriteString(sql[i : i+end+2])
+			i += end + 1
+		case c == ';':
+			b.WriteByte(c)
+			flush()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	flush()
+	return stmts
+}
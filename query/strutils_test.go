@@ -11,3 +11,84 @@ func TestQuote(t *testing.T) {
 		t.Errorf("mismatch want=%s, got=%s", want, got)
 	}
 }
+
+func TestDoubleQuote(t *testing.T) {
+	want := `"id"`
+	got := DoubleQuote("id")
+	if want != got {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+
+	want = `"a""b"`
+	got = DoubleQuote(`a"b`)
+	if want != got {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	want := `'it''s'`
+	got := QuoteLiteral("it's")
+	if want != got {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+}
+
+func TestBracket(t *testing.T) {
+	want := "[id]"
+	got := Bracket("id")
+	if want != got {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+
+	want = "[a]]b]"
+	got = Bracket("a]b")
+	if want != got {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+}
+
+func TestQuoteLiterals(t *testing.T) {
+	want := `'a','it''s'`
+	got := QuoteLiterals([]string{"a", "it's"})
+	if want != got {
+		t.Errorf("mismatch want=%s, got=%s", want, got)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "[Normal] semicolons in quotes",
+			sql:  "-- header\nCREATE TABLE `a;b` (\n  `c` INT COMMENT 'it''s;'\n);\n\nDROP TABLE \"x;y\";\n",
+			want: []string{"CREATE TABLE `a;b` (\n  `c` INT COMMENT 'it''s;'\n);", `DROP TABLE "x;y";`},
+		},
+		{
+			name: "[Normal] GO batch separator",
+			sql:  "SET ANSI_NULLS ON;\nGO\n\nCREATE INDEX [go;] ON [t] ([c])\ngo\n",
+			want: []string{"SET ANSI_NULLS ON;", "CREATE INDEX [go;] ON [t] ([c])"},
+		},
+		{
+			name: "[Normal] statement without semicolon",
+			sql:  "BEGIN;\nCOMMIT -- end",
+			want: []string{"BEGIN;", "COMMIT"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitStatements(tt.sql)
+			if len(got) != len(tt.want) {
+				t.Fatalf("mismatch want=%q, got=%q", tt.want, got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("mismatch want=%q, got=%q", tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package reader
+
+import (
+	"fmt"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+// builder creates keys and indexes by constructors of the dialect.
+// specialIndex creates FULLTEXT and SPATIAL indexes that only MySQL has.
+type builder struct {
+	dialect.Builder
+	specialIndex func(kind, name, table string, columns []string, parser string) (dialect.Index, error)
+}
+
+func newBuilder(d dialect.Dialect) (builder, error) {
+	var special func(kind, name, table string, columns []string, parser string) (dialect.Index, error)
+	switch d.(type) {
+	case mysql.MySQL, *mysql.MySQL:
+		special = mysqlSpecialIndex
+	case sqlite.SQLite, *sqlite.SQLite:
+		special = sqliteSpecialIndex
+	default:
+		return builder{}, fmt.Errorf("%w: %T", ErrNotSupported, d)
+	}
+
+	b, err := dialect.NewBuilder(d)
+	if err != nil {
+		return builder{}, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	return builder{Builder: b, specialIndex: special}, nil
+}
+
+func mysqlSpecialIndex(kind, name, table string, columns []string, parser string) (dialect.Index, error) {
+	if kind == "SPATIAL" {
+		return mysql.AddSpatialIndex(name, columns...), nil
+	}
+	idx := mysql.AddFullTextIndex(name, columns...)
+	if parser != "" {
+		idx = idx.WithParser(parser)
+	}
+	return idx, nil
+}
+
+func sqliteSpecialIndex(kind, name, table string, columns []string, parser string) (dialect.Index, error) {
+	return nil, fmt.Errorf("%w: %s index is not supported by SQLite", ErrSyntax, kind)
+}
@@ -0,0 +1,40 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+// sqliteSchemaQuery selects DDL of tables and indexes stored in the SQLite database.
+// Internal tables (sqlite_sequence etc.) and automatic indexes are excluded.
+const sqliteSchemaQuery = `SELECT sql FROM sqlite_master
+WHERE type IN ('table', 'index') AND sql IS NOT NULL AND name NOT LIKE 'sqlite_%'
+ORDER BY CASE type WHEN 'table' THEN 0 ELSE 1 END, rowid`
+
+// ReadSQLiteDB reads tables from the schema of the SQLite database.
+// db must be opened by SQLite driver (e.g. github.com/mattn/go-sqlite3).
+func ReadSQLiteDB(ctx context.Context, db *sql.DB) ([]dialect.Table, error) {
+	rows, err := db.QueryContext(ctx, sqliteSchemaQuery)
+	if err != nil {
+		return nil, fmt.Errorf("error query sqlite schema: %w", err)
+	}
+	defer rows.Close()
+
+	var stmts []string
+	for rows.Next() {
+		var stmt string
+		if err := rows.Scan(&stmt); err != nil {
+			return nil, fmt.Errorf("error scan sqlite schema: %w", err)
+		}
+		stmts = append(stmts, stmt+";")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error read sqlite schema: %w", err)
+	}
+	return Read(sqlite.SQLite{}, strings.Join(stmts, "\n"))
+}
@@ -0,0 +1,164 @@
+package reader
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenSymbol
+)
+
+// token is a lexical unit of DDL. pos and end are byte offsets in the source.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	end  int
+}
+
+// is reports whether the token is the bare word or symbol (case insensitive).
+func (t token) is(s string) bool {
+	return (t.kind == tokenWord || t.kind == tokenSymbol) && strings.EqualFold(t.text, s)
+}
+
+// tokenize splits DDL into tokens. Comments are skipped.
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '-' && strings.HasPrefix(src[i:], "--"), c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated comment at %d", ErrSyntax, i)
+			}
+			i += end + 4
+		case c == '`' || c == '"' || c == '[' || c == '\'':
+			closer := c
+			if c == '[' {
+				closer = ']'
+			}
+			var b strings.Builder
+			j := i + 1
+			for {
+				if j >= len(src) {
+					return nil, fmt.Errorf("%w: unterminated quote at %d", ErrSyntax, i)
+				}
+				if src[j] == closer {
+					// doubled closing character is an escaped one
+					if j+1 < len(src) && src[j+1] == closer {
+						b.WriteByte(closer)
+						j += 2
+						continue
+					}
+					break
+				}
+				b.WriteByte(src[j])
+				j++
+			}
+			kind := tokenIdent
+			if c == '\'' {
+				kind = tokenString
+			}
+			tokens = append(tokens, token{kind: kind, text: b.String(), pos: i, end: j + 1})
+			i = j + 1
+		case c == '(' || c == ')' || c == ',' || c == ';' || c == '.' || c == '=':
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(c), pos: i, end: i + 1})
+			i++
+		default:
+			j := i
+			for j < len(src) && !strings.ContainsRune(" \t\r\n`\"'[(),;.=", rune(src[j])) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: src[i:j], pos: i, end: j})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// splitStatements splits tokens by semicolon.
+func splitStatements(tokens []token) [][]token {
+	var stmts [][]token
+	start := 0
+	for i, t := range tokens {
+		if t.is(";") {
+			if i > start {
+				stmts = append(stmts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		stmts = append(stmts, tokens[start:])
+	}
+	return stmts
+}
+
+// splitTopLevel splits tokens by commas which are not enclosed in parentheses.
+func splitTopLevel(tokens []token) [][]token {
+	var parts [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case t.is(",") && depth == 0:
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
+
+// closing returns index of the parenthesis that closes tokens[open].
+func closing(tokens []token, open int) (int, error) {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch {
+		case tokens[i].is("("):
+			depth++
+		case tokens[i].is(")"):
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: unbalanced parenthesis at %d", ErrSyntax, tokens[open].pos)
+}
+
+// source returns the source text of tokens with whitespace outside of quotes collapsed.
+func source(src string, tokens []token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, t := range tokens {
+		if i > 0 && t.pos > tokens[i-1].end {
+			b.WriteByte(' ')
+		}
+		b.WriteString(src[t.pos:t.end])
+	}
+	return b.String()
+}
@@ -0,0 +1,503 @@
+package reader
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+)
+
+var (
+	// ErrSyntax means DDL can not be parsed
+	ErrSyntax = errors.New("invalid ddl syntax")
+	// ErrNotSupported means the dialect is not supported by reader
+	ErrNotSupported = errors.New("dialect is not supported by reader")
+)
+
+// ReadFile reads the DDL file and returns tables defined in it.
+func ReadFile(d dialect.Dialect, path string) ([]dialect.Table, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error read ddl file: %w", err)
+	}
+	return Read(d, string(b))
+}
+
+// Read parses CREATE TABLE and CREATE INDEX statements of MySQL or SQLite
+// and returns tables in the order of definition. Other statements
+// (e.g. DROP TABLE, SET, PRAGMA) are ignored.
+//
+// Columns keep their definition as written with the name quoted by the
+// dialect, so DDL generated by ddl-maker can be compared with generated tables.
+func Read(d dialect.Dialect, ddl string) ([]dialect.Table, error) {
+	b, err := newBuilder(d)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := tokenize(ddl)
+	if err != nil {
+		return nil, err
+	}
+
+	r := reader{src: ddl, dialect: d, builder: b, tables: make(map[string]*table)}
+	for _, stmt := range splitStatements(tokens) {
+		if err := r.statement(stmt); err != nil {
+			return nil, err
+		}
+	}
+
+	tables := make([]dialect.Table, 0, len(r.order))
+	for _, name := range r.order {
+		tables = append(tables, *r.tables[name])
+	}
+	return tables, nil
+}
+
+type reader struct {
+	src     string
+	dialect dialect.Dialect
+	builder builder
+	tables  map[string]*table
+	order   []string
+}
+
+func (r *reader) statement(stmt []token) error {
+	if len(stmt) < 2 || !stmt[0].is("CREATE") {
+		return nil
+	}
+
+	i := 1
+	if stmt[i].is("TEMPORARY") || stmt[i].is("TEMP") {
+		i++
+		if i >= len(stmt) {
+			return fmt.Errorf("%w: CREATE %s is not followed by TABLE", ErrSyntax, stmt[i-1].text)
+		}
+	}
+	if stmt[i].is("TABLE") {
+		return r.createTable(stmt[i+1:])
+	}
+
+	unique := false
+	kind := ""
+	switch {
+	case stmt[i].is("UNIQUE"):
+		unique = true
+		i++
+	case stmt[i].is("FULLTEXT"), stmt[i].is("SPATIAL"):
+		kind = strings.ToUpper(stmt[i].text)
+		i++
+	}
+	if i >= len(stmt) {
+		return fmt.Errorf("%w: CREATE %s is not followed by INDEX", ErrSyntax, stmt[i-1].text)
+	}
+	if stmt[i].is("INDEX") {
+		return r.createIndex(stmt[i+1:], unique, kind)
+	}
+	return nil
+}
+
+// createTable parses tokens after CREATE TABLE.
+func (r *reader) createTable(stmt []token) error {
+	stmt = skipIfNotExists(stmt)
+	name, i, err := qualifiedName(stmt)
+	if err != nil {
+		return err
+	}
+	if i >= len(stmt) || !stmt[i].is("(") {
+		return fmt.Errorf("%w: column definitions of %s are not found", ErrSyntax, name)
+	}
+	end, err := closing(stmt, i)
+	if err != nil {
+		return err
+	}
+
+	t := &table{name: name, dialect: r.dialect}
+	var inlinePK []string
+	for _, def := range splitTopLevel(stmt[i+1 : end]) {
+		if len(def) == 0 {
+			continue
+		}
+		pk, err := r.definition(t, def)
+		if err != nil {
+			return fmt.Errorf("error table %s: %w", name, err)
+		}
+		inlinePK = append(inlinePK, pk...)
+	}
+	if t.primaryKey == nil && len(inlinePK) > 0 {
+		t.primaryKey = r.builder.PrimaryKey(inlinePK)
+	}
+	options, err := tableOptions(stmt[end+1:])
+	if err != nil {
+		return fmt.Errorf("error table %s: %w", name, err)
+	}
+	t.options = options
+
+	if _, ok := r.tables[name]; !ok {
+		r.order = append(r.order, name)
+	}
+	r.tables[name] = t
+	return nil
+}
+
+// definition parses a column definition or a table constraint.
+// It returns the column name if the column is declared as PRIMARY KEY inline.
+func (r *reader) definition(t *table, def []token) ([]string, error) {
+	constraint := ""
+	if def[0].is("CONSTRAINT") && len(def) > 2 {
+		constraint = def[1].text
+		def = def[2:]
+	}
+
+	head := def[0]
+	if head.kind == tokenWord {
+		switch strings.ToUpper(head.text) {
+		case "PRIMARY":
+			columns, _, err := columnList(def, 2)
+			if err != nil {
+				return nil, err
+			}
+			t.primaryKey = r.builder.PrimaryKey(columns)
+			return nil, nil
+		case "FOREIGN":
+			fk, err := r.foreignKey(constraint, def)
+			if err != nil {
+				return nil, err
+			}
+			t.foreignKeys = append(t.foreignKeys, fk)
+			return nil, nil
+		case "UNIQUE":
+			name, columns, _, err := indexDefinition(def, 1, constraint)
+			if err != nil {
+				return nil, err
+			}
+			if name == "" {
+				name = fmt.Sprintf("%s_%s_uniq", t.name, strings.Join(columns, "_"))
+			}
+			t.indexes = append(t.indexes, r.builder.UniqueIndex(name, t.name, columns))
+			return nil, nil
+		case "INDEX", "KEY":
+			name, columns, _, err := indexDefinition(def, 1, constraint)
+			if err != nil {
+				return nil, err
+			}
+			t.indexes = append(t.indexes, r.builder.Index(name, t.name, columns))
+			return nil, nil
+		case "FULLTEXT", "SPATIAL":
+			name, columns, next, err := indexDefinition(def, 1, constraint)
+			if err != nil {
+				return nil, err
+			}
+			idx, err := r.builder.specialIndex(strings.ToUpper(head.text), name, t.name, columns, parser(def[next:]))
+			if err != nil {
+				return nil, err
+			}
+			t.indexes = append(t.indexes, idx)
+			return nil, nil
+		case "CHECK":
+			if len(def) < 2 || !def[1].is("(") {
+				return nil, fmt.Errorf("%w: CHECK without expression at %d", ErrSyntax, head.pos)
+			}
+			end, err := closing(def, 1)
+			if err != nil {
+				return nil, err
+			}
+			if constraint == "" {
+				// MySQL names unnamed check constraints <table>_chk_<seq>
+				t.unnamedChecks++
+				constraint = fmt.Sprintf("%s_chk_%d", t.name, t.unnamedChecks)
+			}
+			t.checks = append(t.checks, r.builder.Check(constraint, source(r.src, def[2:end])))
+			return nil, nil
+		}
+	}
+
+	if head.kind != tokenWord && head.kind != tokenIdent {
+		return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, head.text, head.pos)
+	}
+	c := column{
+		name:       head.text,
+		definition: strings.TrimSpace(r.dialect.Quote(head.text) + " " + source(r.src, def[1:])),
+		null:       true,
+	}
+	i := 1
+	for i < len(def) && def[i].kind == tokenWord && !attributeKeywords[strings.ToUpper(def[i].text)] {
+		i++
+		if i < len(def) && def[i].is("(") {
+			end, err := closing(def, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end + 1
+		}
+	}
+	c.typeName = source(r.src, def[1:i])
+
+	var pk []string
+	for ; i < len(def); i++ {
+		switch {
+		case def[i].is("NOT") && i+1 < len(def) && def[i+1].is("NULL"):
+			c.null = false
+			i++
+		case def[i].is("AUTO_INCREMENT"), def[i].is("AUTOINCREMENT"):
+			c.autoIncrement = true
+		case def[i].is("DEFAULT") && i+1 < len(def):
+			value := expression(r.src, def, i+1)
+			c.defaultValue = &value
+		case def[i].is("ON") && i+2 < len(def) && def[i+1].is("UPDATE"):
+			value := expression(r.src, def, i+2)
+			c.onUpdate = &value
+		case def[i].is("COMMENT") && i+1 < len(def) && def[i+1].kind == tokenString:
+			comment := def[i+1].text
+			c.comment = &comment
+		case def[i].is("PRIMARY") && i+1 < len(def) && def[i+1].is("KEY"):
+			c.null = false
+			pk = append(pk, c.name)
+		case def[i].is("AS") && i+1 < len(def) && def[i+1].is("("):
+			// [GENERATED ALWAYS] AS (expression) [VIRTUAL | STORED]
+			end, err := closing(def, i+1)
+			if err != nil {
+				return nil, err
+			}
+			value := source(r.src, def[i+2:end])
+			c.generated = &value
+			i = end
+		case def[i].is("STORED"), def[i].is("PERSISTENT"):
+			c.stored = true
+		case def[i].is("UNIQUE"):
+			c.unique = true
+		case def[i].is("CHARACTER") && i+2 < len(def) && def[i+1].is("SET"):
+			c.charset = def[i+2].text
+			i += 2
+		case def[i].is("CHARSET") && i+1 < len(def):
+			c.charset = def[i+1].text
+			i++
+		case def[i].is("COLLATE") && i+1 < len(def):
+			c.collation = def[i+1].text
+			i++
+		}
+	}
+	t.columns = append(t.columns, c)
+	return pk, nil
+}
+
+// tableOptions parses MySQL table options after the column definitions
+// (e.g. ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='users'). Unknown options are ignored.
+func tableOptions(tokens []token) (dialect.TableOptions, error) {
+	var options dialect.TableOptions
+	for i := 0; i < len(tokens); i++ {
+		key := strings.ToUpper(tokens[i].text)
+		if key == "CHARACTER" && i+1 < len(tokens) && tokens[i+1].is("SET") {
+			key = "CHARSET"
+			i++
+		}
+		if tokens[i].kind != tokenWord {
+			continue
+		}
+		j := i + 1
+		if j < len(tokens) && tokens[j].is("=") {
+			j++
+		}
+		if j >= len(tokens) {
+			break
+		}
+		value := tokens[j].text
+
+		var err error
+		switch key {
+		case "ENGINE":
+			options.Engine = value
+		case "CHARSET":
+			options.Charset = value
+		case "COLLATE":
+			options.Collation = value
+		case "ROW_FORMAT":
+			options.RowFormat = value
+		case "COMMENT":
+			options.Comment = value
+		case "AUTO_INCREMENT":
+			options.AutoIncrement, err = strconv.ParseUint(value, 10, 64)
+		case "KEY_BLOCK_SIZE":
+			options.KeyBlockSize, err = strconv.ParseUint(value, 10, 64)
+		default:
+			continue
+		}
+		if err != nil {
+			return options, fmt.Errorf("%w: %s of table options: %v", ErrSyntax, key, err)
+		}
+		i = j
+	}
+	return options, nil
+}
+
+// attributeKeywords are words that end the type of column definition.
+var attributeKeywords = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "KEY": true,
+	"AUTO_INCREMENT": true, "AUTOINCREMENT": true, "COMMENT": true, "ON": true,
+	"UNIQUE": true, "REFERENCES": true, "CHECK": true, "COLLATE": true,
+	"CHARACTER": true, "CHARSET": true, "GENERATED": true, "AS": true,
+	"CONSTRAINT": true, "IDENTITY": true,
+}
+
+// expression returns the source of a single value or function call at def[i].
+func expression(src string, def []token, i int) string {
+	end := i + 1
+	if end < len(def) && def[end].is("(") {
+		if closed, err := closing(def, end); err == nil {
+			end = closed + 1
+		}
+	}
+	return source(src, def[i:end])
+}
+
+// foreignKey parses FOREIGN KEY (...) REFERENCES table (...) [ON DELETE ...] [ON UPDATE ...].
+// name is the constraint name, and empty string means the foreign key is not named.
+func (r *reader) foreignKey(name string, def []token) (dialect.ForeignKey, error) {
+	columns, i, err := columnList(def, 2)
+	if err != nil {
+		return nil, err
+	}
+	if i >= len(def) || !def[i].is("REFERENCES") {
+		return nil, fmt.Errorf("%w: REFERENCES is not found in foreign key", ErrSyntax)
+	}
+	refTable, j, err := qualifiedName(def[i+1:])
+	if err != nil {
+		return nil, err
+	}
+	refColumns, i, err := columnList(def, i+1+j)
+	if err != nil {
+		return nil, err
+	}
+
+	var onDelete, onUpdate string
+	for i < len(def) {
+		if !def[i].is("ON") || i+2 >= len(def) {
+			i++
+			continue
+		}
+		event := strings.ToUpper(def[i+1].text)
+		action := strings.ToUpper(def[i+2].text)
+		i += 3
+		if (action == "SET" || action == "NO") && i < len(def) {
+			action += " " + strings.ToUpper(def[i].text)
+			i++
+		}
+		switch event {
+		case "DELETE":
+			onDelete = action
+		case "UPDATE":
+			onUpdate = action
+		}
+	}
+
+	return r.builder.ForeignKey(name, columns, refColumns, refTable, onDelete, onUpdate), nil
+}
+
+// createIndex parses tokens after CREATE [UNIQUE] INDEX.
+func (r *reader) createIndex(stmt []token, unique bool, kind string) error {
+	stmt = skipIfNotExists(stmt)
+	if len(stmt) < 3 {
+		return fmt.Errorf("%w: invalid CREATE INDEX statement", ErrSyntax)
+	}
+	name := stmt[0].text
+	if !stmt[1].is("ON") {
+		return fmt.Errorf("%w: ON is not found in CREATE INDEX %s", ErrSyntax, name)
+	}
+	tableName, i, err := qualifiedName(stmt[2:])
+	if err != nil {
+		return err
+	}
+	columns, next, err := columnList(stmt, i+2)
+	if err != nil {
+		return err
+	}
+
+	t, ok := r.tables[tableName]
+	if !ok {
+		return fmt.Errorf("%w: table %s of index %s is not defined", ErrSyntax, tableName, name)
+	}
+
+	var idx dialect.Index
+	switch {
+	case unique:
+		idx = r.builder.UniqueIndex(name, tableName, columns)
+	case kind != "":
+		idx, err = r.builder.specialIndex(kind, name, tableName, columns, parser(stmt[next:]))
+		if err != nil {
+			return err
+		}
+	default:
+		idx = r.builder.Index(name, tableName, columns)
+	}
+	t.indexes = append(t.indexes, idx)
+	return nil
+}
+
+// indexDefinition parses [KEY|INDEX] [name] (columns) from def[i:].
+func indexDefinition(def []token, i int, name string) (string, []string, int, error) {
+	if i < len(def) && (def[i].is("KEY") || def[i].is("INDEX")) {
+		i++
+	}
+	if i < len(def) && !def[i].is("(") {
+		name = def[i].text
+		i++
+	}
+	columns, next, err := columnList(def, i)
+	return name, columns, next, err
+}
+
+// columnList parses (a, b(10), c DESC) from tokens[open:] and returns column
+// names and the index after the closing parenthesis.
+func columnList(tokens []token, open int) ([]string, int, error) {
+	for open < len(tokens) && !tokens[open].is("(") {
+		open++
+	}
+	if open >= len(tokens) {
+		return nil, 0, fmt.Errorf("%w: column list is not found", ErrSyntax)
+	}
+	end, err := closing(tokens, open)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var columns []string
+	for _, part := range splitTopLevel(tokens[open+1 : end]) {
+		if len(part) == 0 {
+			return nil, 0, fmt.Errorf("%w: empty column in column list", ErrSyntax)
+		}
+		columns = append(columns, part[0].text)
+	}
+	return columns, end + 1, nil
+}
+
+// qualifiedName returns the last part of schema.table and the index after it.
+func qualifiedName(tokens []token) (string, int, error) {
+	if len(tokens) == 0 || (tokens[0].kind != tokenWord && tokens[0].kind != tokenIdent) {
+		return "", 0, fmt.Errorf("%w: table name is not found", ErrSyntax)
+	}
+	i := 0
+	for i+2 < len(tokens) && tokens[i+1].is(".") {
+		i += 2
+	}
+	return tokens[i].text, i + 1, nil
+}
+
+func skipIfNotExists(tokens []token) []token {
+	if len(tokens) > 3 && tokens[0].is("IF") && tokens[1].is("NOT") && tokens[2].is("EXISTS") {
+		return tokens[3:]
+	}
+	return tokens
+}
+
+// parser returns parser name of WITH PARSER clause.
+func parser(tokens []token) string {
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokens[i].is("WITH") && tokens[i+1].is("PARSER") {
+			return tokens[i+2].text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,242 @@
+package reader
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"text/template"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mock"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+func TestReadFile_SQLiteRoundTrip(t *testing.T) {
+	d := sqlite.SQLite{}
+	tables, err := ReadFile(d, "../testdata/sqlite/golden.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := template.Must(template.New("ddl").Parse(d.TableTemplate()))
+	var got bytes.Buffer
+	got.WriteString(d.HeaderTemplate())
+	for _, table := range tables {
+		if err := tmpl.Execute(&got, table); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got.WriteString(d.FooterTemplate())
+
+	want, err := os.ReadFile("../testdata/sqlite/golden.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(want), got.String()); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+}
+
+func TestReadFile_MySQL(t *testing.T) {
+	tables, err := ReadFile(mysql.MySQL{}, "../_example/sql/master.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.Name())
+	}
+	if diff := cmp.Diff([]string{"`player`", "`entry`", "`player_comment`", "`bookmark`"}, names); diff != "" {
+		t.Fatalf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+
+	entry := tables[1]
+	if got := entry.PrimaryKey().ToSQL(); got != "PRIMARY KEY (`id`, `created_at`)" {
+		t.Errorf("mismatch primary key: %s", got)
+	}
+	var indexes []string
+	for _, idx := range entry.Indexes().Sort() {
+		indexes = append(indexes, idx.ToSQL())
+	}
+	want := []string{
+		"FULLTEXT `full_text_idx` (`content`) WITH PARSER `ngram`",
+		"INDEX `created_at_idx` (`created_at`)",
+		"INDEX `title_idx` (`title`)",
+		"UNIQUE `created_at_uniq_idx` (`created_at`)",
+	}
+	if diff := cmp.Diff(want, indexes); diff != "" {
+		t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+	}
+	got, err := entry.Columns()[2].ToSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "`public` TINYINT(1) NOT NULL DEFAULT 0" {
+		t.Errorf("mismatch column: %s", got)
+	}
+
+	comment := tables[2]
+	if len(comment.ForeignKeys()) != 2 {
+		t.Fatalf("mismatch foreign keys: %d", len(comment.ForeignKeys()))
+	}
+	if got := comment.ForeignKeys()[0].ToSQL(); got != "FOREIGN KEY (`entry_id`) REFERENCES `entry` (`id`)" {
+		t.Errorf("mismatch foreign key: %s", got)
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Run("[Normal] hand-written MySQL DDL", func(t *testing.T) {
+		ddl := `
+-- users
+CREATE TABLE IF NOT EXISTS app.users (
+  id bigint unsigned NOT NULL AUTO_INCREMENT,
+  email varchar(255)   NOT NULL COMMENT 'login, address',
+  team_id INT NOT NULL,
+  /* profile */
+  bio TEXT,
+  PRIMARY KEY (id),
+  UNIQUE KEY uniq_email (email(191)),
+  KEY idx_team (team_id),
+  CONSTRAINT fk_team FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 AUTO_INCREMENT=10 COMMENT='app users';
+`
+		tables, err := Read(mysql.MySQL{}, ddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tables) != 1 {
+			t.Fatalf("mismatch tables: %d", len(tables))
+		}
+		users := tables[0]
+		if users.Name() != "`users`" {
+			t.Errorf("mismatch table name: %s", users.Name())
+		}
+
+		var columns []string
+		for _, c := range users.Columns() {
+			sql, err := c.ToSQL()
+			if err != nil {
+				t.Fatal(err)
+			}
+			columns = append(columns, sql)
+		}
+		want := []string{
+			"`id` bigint unsigned NOT NULL AUTO_INCREMENT",
+			"`email` varchar(255) NOT NULL COMMENT 'login, address'",
+			"`team_id` INT NOT NULL",
+			"`bio` TEXT",
+		}
+		if diff := cmp.Diff(want, columns); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+		if got := users.Columns()[1].Comment(); got != "login, address" {
+			t.Errorf("mismatch comment: %s", got)
+		}
+		id := users.Columns()[0].(Column)
+		if id.Type() != "bigint unsigned" || id.Null() || !id.AutoIncrement() {
+			t.Errorf("mismatch column attributes: type=%s, null=%v, auto=%v", id.Type(), id.Null(), id.AutoIncrement())
+		}
+		bio := users.Columns()[3].(Column)
+		if bio.Type() != "TEXT" || !bio.Null() || bio.HasComment() {
+			t.Errorf("mismatch column attributes: type=%s, null=%v, comment=%v", bio.Type(), bio.Null(), bio.HasComment())
+		}
+
+		var indexes []string
+		for _, idx := range users.Indexes() {
+			indexes = append(indexes, idx.ToSQL())
+		}
+		want = []string{
+			"UNIQUE `uniq_email` (`email`)",
+			"INDEX `idx_team` (`team_id`)",
+		}
+		if diff := cmp.Diff(want, indexes); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+
+		if got := users.ForeignKeys()[0].ToSQL(); got != "CONSTRAINT `fk_team` FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`) ON DELETE SET NULL ON UPDATE CASCADE" {
+			t.Errorf("mismatch foreign key: %s", got)
+		}
+
+		wantOptions := dialect.TableOptions{Comment: "app users", Engine: "InnoDB", Charset: "utf8mb4", AutoIncrement: 10}
+		if diff := cmp.Diff(wantOptions, users.Options()); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] SQLite inline primary key and CREATE INDEX", func(t *testing.T) {
+		ddl := `CREATE TABLE "tag" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "name" TEXT NOT NULL);
+CREATE UNIQUE INDEX IF NOT EXISTS "tag_name" ON "tag" ("name");`
+		tables, err := Read(sqlite.SQLite{}, ddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tag := tables[0]
+		if got := tag.PrimaryKey().ToSQL(); got != "PRIMARY KEY (`id`)" {
+			t.Errorf("mismatch primary key: %s", got)
+		}
+		if got := tag.Indexes()[0].ToSQL(); got != "CREATE UNIQUE INDEX `tag_name` ON `tag` (`name`);" {
+			t.Errorf("mismatch index: %s", got)
+		}
+	})
+
+	t.Run("[Normal] CHECK constraints", func(t *testing.T) {
+		ddl := "CREATE TABLE `item` (\n" +
+			"  `price` INTEGER NOT NULL,\n" +
+			"  `stock` INTEGER NOT NULL,\n" +
+			"  CONSTRAINT `item_price_check` CHECK (price >= 0),\n" +
+			"  CHECK (stock >= 0 AND stock <= price)\n" +
+			");"
+		tables, err := Read(mysql.MySQL{}, ddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var checks []string
+		for _, c := range tables[0].Checks() {
+			checks = append(checks, c.ToSQL())
+		}
+		want := []string{
+			"CONSTRAINT `item_price_check` CHECK (price >= 0)",
+			"CONSTRAINT `item_chk_1` CHECK (stock >= 0 AND stock <= price)",
+		}
+		if diff := cmp.Diff(want, checks); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Error] unbalanced parenthesis", func(t *testing.T) {
+		_, err := Read(mysql.MySQL{}, "CREATE TABLE `a` (`id` INT NOT NULL")
+		if !errors.Is(err, ErrSyntax) {
+			t.Errorf("mismatch want=%v, got=%v", ErrSyntax, err)
+		}
+	})
+
+	for _, ddl := range []string{"CREATE TEMP;", "CREATE TEMPORARY", "CREATE UNIQUE;", "CREATE FULLTEXT"} {
+		t.Run("[Error] incomplete statement "+ddl, func(t *testing.T) {
+			_, err := Read(mysql.MySQL{}, ddl)
+			if !errors.Is(err, ErrSyntax) {
+				t.Errorf("mismatch want=%v, got=%v", ErrSyntax, err)
+			}
+		})
+	}
+
+	t.Run("[Error] index on unknown table", func(t *testing.T) {
+		_, err := Read(sqlite.SQLite{}, "CREATE INDEX `a_idx` ON `a` (`id`);")
+		if !errors.Is(err, ErrSyntax) {
+			t.Errorf("mismatch want=%v, got=%v", ErrSyntax, err)
+		}
+	})
+
+	t.Run("[Error] dialect is not supported", func(t *testing.T) {
+		_, err := Read(mock.SQLMock{}, "")
+		if !errors.Is(err, ErrNotSupported) {
+			t.Errorf("mismatch want=%v, got=%v", ErrNotSupported, err)
+		}
+	})
+}
+
+// Tables read from DDL satisfy dialect.Table.
+var _ dialect.Table = table{}
@@ -0,0 +1,194 @@
+package reader
+
+import (
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/spec"
+)
+
+// table is the table read from DDL
+type table struct {
+	name        string
+	primaryKey  dialect.PrimaryKey
+	foreignKeys dialect.ForeignKeys
+	columns     []dialect.Column
+	indexes     dialect.Indexes
+	checks      dialect.Checks
+	options     dialect.TableOptions
+	dialect     dialect.Dialect
+	// unnamedChecks is the number of check constraints declared without name
+	unnamedChecks int
+}
+
+func (t table) Name() string {
+	return t.dialect.Quote(t.name)
+}
+
+func (t table) PrimaryKey() dialect.PrimaryKey {
+	return t.primaryKey
+}
+
+func (t table) ForeignKeys() dialect.ForeignKeys {
+	return t.foreignKeys
+}
+
+func (t table) Columns() []dialect.Column {
+	return t.columns
+}
+
+func (t table) Indexes() dialect.Indexes {
+	return t.indexes
+}
+
+func (t table) Checks() dialect.Checks {
+	return t.checks
+}
+
+func (t table) Options() dialect.TableOptions {
+	return t.options
+}
+
+func (t table) Dialect() dialect.Dialect {
+	return t.dialect
+}
+
+// Column is the column read from DDL with its parsed attributes.
+type Column interface {
+	dialect.Column
+	// Type return SQL type as written (e.g. VARCHAR(191), BIGINT unsigned)
+	Type() string
+	// Null reports whether the column is nullable
+	Null() bool
+	// AutoIncrement reports whether the column is auto increment
+	AutoIncrement() bool
+	// Default return default value expression if it is declared
+	Default() (string, bool)
+	// OnUpdate return ON UPDATE expression if it is declared
+	OnUpdate() (string, bool)
+	// HasComment reports whether the comment is declared
+	HasComment() bool
+	// Generated return the expression of generated column if it is declared
+	Generated() (string, bool)
+	// Stored reports whether the generated column is stored
+	Stored() bool
+	// Charset return CHARACTER SET of the column if it is declared
+	Charset() string
+	// Collation return COLLATE of the column if it is declared
+	Collation() string
+}
+
+// column is the column read from DDL
+type column struct {
+	name          string
+	definition    string
+	typeName      string
+	null          bool
+	autoIncrement bool
+	defaultValue  *string
+	onUpdate      *string
+	comment       *string
+	generated     *string
+	stored        bool
+	unique        bool
+	charset       string
+	collation     string
+}
+
+// Name return column name
+func (c column) Name() string {
+	return c.name
+}
+
+// Comment return column comment. If it is not declared, it returns column name.
+func (c column) Comment() string {
+	if c.comment != nil {
+		return *c.comment
+	}
+	return c.name
+}
+
+// ToSQL return column definition
+func (c column) ToSQL() (string, error) {
+	return c.definition, nil
+}
+
+// Type return SQL type as written
+func (c column) Type() string {
+	return c.typeName
+}
+
+// Null reports whether the column is nullable
+func (c column) Null() bool {
+	return c.null
+}
+
+// AutoIncrement reports whether the column is auto increment
+func (c column) AutoIncrement() bool {
+	return c.autoIncrement
+}
+
+// Default return default value expression if it is declared
+func (c column) Default() (string, bool) {
+	if c.defaultValue == nil {
+		return "", false
+	}
+	return *c.defaultValue, true
+}
+
+// OnUpdate return ON UPDATE expression if it is declared
+func (c column) OnUpdate() (string, bool) {
+	if c.onUpdate == nil {
+		return "", false
+	}
+	return *c.onUpdate, true
+}
+
+// HasComment reports whether the comment is declared
+func (c column) HasComment() bool {
+	return c.comment != nil
+}
+
+// Generated return the expression of generated column if it is declared
+func (c column) Generated() (string, bool) {
+	if c.generated == nil {
+		return "", false
+	}
+	return *c.generated, true
+}
+
+// Stored reports whether the generated column is stored
+func (c column) Stored() bool {
+	return c.stored
+}
+
+// Charset return CHARACTER SET of the column if it is declared
+func (c column) Charset() string {
+	return c.charset
+}
+
+// Collation return COLLATE of the column if it is declared
+func (c column) Collation() string {
+	return c.collation
+}
+
+// Spec return the parsed definition of the column
+func (c column) Spec() (spec.Column, error) {
+	sc := spec.Column{
+		Attributes: spec.Attributes{
+			Null:          c.null,
+			AutoIncrement: c.autoIncrement,
+			Comment:       c.Comment(),
+		},
+		Stored: c.stored,
+		Unique: c.unique,
+	}
+	if c.defaultValue != nil {
+		sc.Default = *c.defaultValue
+	}
+	if c.onUpdate != nil {
+		sc.OnUpdate = *c.onUpdate
+	}
+	if c.generated != nil {
+		sc.Generated = *c.generated
+	}
+	return sc, nil
+}
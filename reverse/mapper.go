@@ -0,0 +1,238 @@
+package reverse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+const (
+	dialectPath = "github.com/mnhkahn/ddl-maker/dialect"
+	mysqlPath   = "github.com/mnhkahn/ddl-maker/dialect/mysql"
+	sqlitePath  = "github.com/mnhkahn/ddl-maker/dialect/sqlite"
+)
+
+// sqlType is the column type read from DDL (e.g. VARCHAR(191), BIGINT unsigned)
+type sqlType struct {
+	name     string
+	args     []string
+	unsigned bool
+}
+
+// parseType splits SQL type into lower cased name, arguments and unsigned flag.
+func parseType(s string) sqlType {
+	var t sqlType
+	rest := s
+	if open := strings.Index(s, "("); open >= 0 {
+		if end := strings.Index(s[open:], ")"); end >= 0 {
+			for _, arg := range strings.Split(s[open+1:open+end], ",") {
+				t.args = append(t.args, strings.TrimSpace(arg))
+			}
+			rest = s[:open] + " " + s[open+end+1:]
+		}
+	}
+	words := strings.Fields(strings.ToLower(rest))
+	for i, w := range words {
+		switch {
+		case w == "unsigned":
+			t.unsigned = true
+		case i == 0:
+			t.name = w
+		}
+	}
+	return t
+}
+
+// goType is the Go type of the struct field and ddl tags that keep the SQL type.
+type goType struct {
+	name string
+	// path is import path of the package of the type
+	path string
+	tags []string
+	// pointer means the type becomes the pointer when the column is nullable
+	pointer bool
+}
+
+// mapper converts types and indexes of the dialect into Go source.
+type mapper interface {
+	pkg() string
+	path() string
+	goType(t sqlType) (goType, bool)
+	index(idx dialect.Index, table string) (string, error)
+}
+
+func newMapper(d dialect.Dialect) (mapper, error) {
+	switch d.(type) {
+	case mysql.MySQL, *mysql.MySQL:
+		return mysqlMapper{}, nil
+	case sqlite.SQLite, *sqlite.SQLite:
+		return sqliteMapper{}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrNotSupported, d)
+}
+
+func scalar(name string, tags ...string) goType {
+	return goType{name: name, tags: tags, pointer: true}
+}
+
+func sized(t goType, args []string, defaultSize string) goType {
+	if len(args) > 0 && args[0] != defaultSize {
+		t.tags = append(t.tags, "size="+args[0])
+	}
+	return t
+}
+
+// decimal keeps precision and scale by tags. The value is read into string
+// to avoid losing precision.
+func decimal(args []string) goType {
+	t := scalar("string", "type=decimal")
+	if len(args) > 0 {
+		t.tags = append(t.tags, "precision="+args[0])
+	}
+	if len(args) > 1 {
+		t.tags = append(t.tags, "scale="+args[1])
+	}
+	return t
+}
+
+// enum keeps values of ENUM and SET by the tag. It returns false when the
+// values can not be written in the tag.
+func enum(kind string, args []string) (goType, bool) {
+	values := make([]string, 0, len(args))
+	for _, arg := range args {
+		v := strings.ReplaceAll(strings.Trim(arg, "'"), "''", "'")
+		if !tagValue(v) || strings.Contains(v, "|") {
+			return goType{}, false
+		}
+		values = append(values, v)
+	}
+	return scalar("string", kind+"="+strings.Join(values, "|")), len(values) > 0
+}
+
+var (
+	bytesType = goType{name: "[]byte"}
+	jsonType  = goType{name: "json.RawMessage", path: "encoding/json"}
+	timeType  = goType{name: "time.Time", path: "time", pointer: true}
+)
+
+type mysqlMapper struct{}
+
+func (mysqlMapper) pkg() string  { return "mysql" }
+func (mysqlMapper) path() string { return mysqlPath }
+
+func (mysqlMapper) goType(t sqlType) (goType, bool) {
+	integer := func(bits string) goType {
+		if t.unsigned {
+			return scalar("uint" + bits)
+		}
+		return scalar("int" + bits)
+	}
+
+	switch t.name {
+	case "tinyint":
+		if len(t.args) == 1 && t.args[0] == "1" {
+			return scalar("bool"), true
+		}
+		return integer("8"), true
+	case "smallint":
+		return integer("16"), true
+	case "mediumint", "int", "integer":
+		return integer("32"), true
+	case "bigint":
+		return integer("64"), true
+	case "float":
+		return scalar("float32"), true
+	case "double", "real":
+		return scalar("float64"), true
+	case "decimal", "numeric":
+		return decimal(t.args), true
+	case "enum", "set":
+		return enum(t.name, t.args)
+	case "varchar", "char":
+		return sized(scalar("string"), t.args, "191"), true
+	case "tinytext", "text", "mediumtext", "longtext":
+		return scalar("string", "type="+t.name), true
+	case "varbinary", "binary":
+		return sized(bytesType, t.args, "767"), true
+	case "tinyblob", "blob", "mediumblob", "longblob", "geometry":
+		b := bytesType
+		b.tags = []string{"type=" + t.name}
+		return b, true
+	case "datetime", "timestamp":
+		return sized(timeType, t.args, ""), true
+	case "date":
+		d := timeType
+		d.tags = []string{"type=date"}
+		return d, true
+	case "time":
+		return scalar("string", "type=time"), true
+	case "json":
+		return jsonType, true
+	}
+	return goType{}, false
+}
+
+func (mysqlMapper) index(idx dialect.Index, table string) (string, error) {
+	name := fmt.Sprintf("%q", unquote(idx.Name()))
+	if columns := quoteAll(idx.Columns()); columns != "" {
+		name += ", " + columns
+	}
+	switch v := idx.(type) {
+	case mysql.Index:
+		return fmt.Sprintf("mysql.AddIndex(%s)", name), nil
+	case mysql.UniqueIndex:
+		return fmt.Sprintf("mysql.AddUniqueIndex(%s)", name), nil
+	case mysql.FullTextIndex:
+		if v.Parser() != "" {
+			return fmt.Sprintf("mysql.AddFullTextIndex(%s).WithParser(%q)", name, v.Parser()), nil
+		}
+		return fmt.Sprintf("mysql.AddFullTextIndex(%s)", name), nil
+	case mysql.SpatialIndex:
+		return fmt.Sprintf("mysql.AddSpatialIndex(%s)", name), nil
+	}
+	return "", fmt.Errorf("%w: index %T", ErrNotSupported, idx)
+}
+
+type sqliteMapper struct{}
+
+func (sqliteMapper) pkg() string  { return "sqlite" }
+func (sqliteMapper) path() string { return sqlitePath }
+
+// goType follows the rules of type affinity of SQLite.
+// https://www.sqlite.org/datatype3.html#determination_of_column_affinity
+func (sqliteMapper) goType(t sqlType) (goType, bool) {
+	switch {
+	case t.name == "json":
+		return jsonType, true
+	case t.name == "boolean", t.name == "bool":
+		return scalar("bool"), true
+	case strings.Contains(t.name, "int"):
+		return scalar("int64"), true
+	case strings.Contains(t.name, "char"), strings.Contains(t.name, "clob"), strings.Contains(t.name, "text"):
+		return scalar("string"), true
+	case strings.Contains(t.name, "blob"), t.name == "":
+		return bytesType, true
+	case strings.Contains(t.name, "real"), strings.Contains(t.name, "floa"), strings.Contains(t.name, "doub"):
+		return scalar("float64"), true
+	case t.name == "numeric", t.name == "decimal":
+		return decimal(t.args), true
+	}
+	return goType{}, false
+}
+
+func (sqliteMapper) index(idx dialect.Index, table string) (string, error) {
+	args := fmt.Sprintf("%q, %q", unquote(idx.Name()), table)
+	if columns := quoteAll(idx.Columns()); columns != "" {
+		args += ", " + columns
+	}
+	switch idx.(type) {
+	case sqlite.Index:
+		return fmt.Sprintf("sqlite.AddIndex(%s)", args), nil
+	case sqlite.UniqueIndex:
+		return fmt.Sprintf("sqlite.AddUniqueIndex(%s)", args), nil
+	}
+	return "", fmt.Errorf("%w: index %T", ErrNotSupported, idx)
+}
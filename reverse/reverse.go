@@ -0,0 +1,366 @@
+// Package reverse generates Go structs for ddl-maker from existing tables.
+// Tables are usually read from DDL by the reader package.
+package reverse
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/reader"
+	"github.com/nao1215/nameconv"
+)
+
+var (
+	// ErrNotSupported means the dialect is not supported by reverse generation
+	ErrNotSupported = errors.New("dialect is not supported by reverse generation")
+	// ErrInvalidColumn means the column does not have parsed attributes
+	ErrInvalidColumn = errors.New("column does not have attributes read from ddl")
+)
+
+// initialisms are upper cased in Go names. They are converted back to
+// the same snake case name by ddl-maker (e.g. PlayerID -> player_id).
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "UID": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// Generate writes Go source of package pkg to w. Each table becomes a struct
+// with ddl tags and Table, PrimaryKey, Indexes, ForeignKeys, TableOptions and Checks methods that
+// use the constructors of the dialect package (mysql or sqlite).
+//
+// Columns must implement reader.Column. Columns whose SQL type can not be
+// expressed by ddl-maker are generated with ddl:"-" and the original type in a comment.
+// The source is a starting point to be edited, so it is not marked as generated code.
+func Generate(w io.Writer, d dialect.Dialect, pkg string, tables []dialect.Table) error {
+	m, err := newMapper(d)
+	if err != nil {
+		return err
+	}
+
+	g := &generator{mapper: m, imports: make(map[string]struct{})}
+	var body bytes.Buffer
+	for _, t := range tables {
+		if err := g.table(&body, t); err != nil {
+			return fmt.Errorf("error generate struct of %s: %w", t.Name(), err)
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		// standard packages come first, separated from the others by an empty line
+		var std, others []string
+		for path := range g.imports {
+			if strings.Contains(path, ".") {
+				others = append(others, strconv.Quote(path))
+			} else {
+				std = append(std, strconv.Quote(path))
+			}
+		}
+		sort.Strings(std)
+		sort.Strings(others)
+		groups := strings.Join(std, "\n")
+		if len(std) > 0 && len(others) > 0 {
+			groups += "\n\n"
+		}
+		groups += strings.Join(others, "\n")
+		fmt.Fprintf(&src, "import (\n%s\n)\n", groups)
+	}
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("error format generated source: %w", err)
+	}
+	if _, err := w.Write(formatted); err != nil {
+		return fmt.Errorf("error write generated source: %w", err)
+	}
+	return nil
+}
+
+type generator struct {
+	mapper  mapper
+	imports map[string]struct{}
+}
+
+func (g *generator) use(path string) {
+	if path != "" {
+		g.imports[path] = struct{}{}
+	}
+}
+
+func (g *generator) table(w io.Writer, t dialect.Table) error {
+	tableName := unquote(t.Name())
+	structName := goName(tableName)
+	recv := strings.ToLower(structName[:1])
+
+	pk := make(map[string]bool)
+	if t.PrimaryKey() != nil {
+		for _, c := range t.PrimaryKey().Columns() {
+			pk[unquote(c)] = true
+		}
+	}
+
+	fmt.Fprintf(w, "\n// %s is the struct of %s table.\n", structName, tableName)
+	fmt.Fprintf(w, "type %s struct {\n", structName)
+	for _, c := range t.Columns() {
+		if err := g.field(w, c, pk[c.Name()]); err != nil {
+			return err
+		}
+	}
+	fmt.Fprint(w, "}\n")
+
+	fmt.Fprintf(w, "\n// Table return table name\nfunc (%s *%s) Table() string {\n\treturn %q\n}\n",
+		recv, structName, tableName)
+
+	pkg := g.mapper.pkg()
+	if t.PrimaryKey() != nil && len(t.PrimaryKey().Columns()) > 0 {
+		g.use(dialectPath)
+		g.use(g.mapper.path())
+		fmt.Fprintf(w, "\n// PrimaryKey return primary key of %s table\nfunc (%s *%s) PrimaryKey() dialect.PrimaryKey {\n\treturn %s.AddPrimaryKey(%s)\n}\n",
+			tableName, recv, structName, pkg, quoteAll(t.PrimaryKey().Columns()))
+	}
+
+	if len(t.Indexes()) > 0 {
+		g.use(dialectPath)
+		g.use(g.mapper.path())
+		fmt.Fprintf(w, "\n// Indexes return indexes of %s table\nfunc (%s *%s) Indexes() dialect.Indexes {\n\treturn dialect.Indexes{\n",
+			tableName, recv, structName)
+		for _, idx := range t.Indexes() {
+			expr, err := g.mapper.index(idx, tableName)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "\t\t%s,\n", expr)
+		}
+		fmt.Fprint(w, "\t}\n}\n")
+	}
+
+	if len(t.ForeignKeys()) > 0 {
+		g.use(dialectPath)
+		g.use(g.mapper.path())
+		fmt.Fprintf(w, "\n// ForeignKeys return foreign keys of %s table\nfunc (%s *%s) ForeignKeys() dialect.ForeignKeys {\n\treturn dialect.ForeignKeys{\n",
+			tableName, recv, structName)
+		for _, fk := range t.ForeignKeys() {
+			fmt.Fprintf(w, "\t\t%s,\n", foreignKey(pkg, fk))
+		}
+		fmt.Fprint(w, "\t}\n}\n")
+	}
+
+	if options := tableOptions(t.Options()); options != "" {
+		g.use(dialectPath)
+		fmt.Fprintf(w, "\n// TableOptions return options of %s table\nfunc (%s *%s) TableOptions() dialect.TableOptions {\n\treturn dialect.TableOptions{%s}\n}\n",
+			tableName, recv, structName, options)
+	}
+
+	if len(t.Checks()) > 0 {
+		g.use(dialectPath)
+		g.use(g.mapper.path())
+		fmt.Fprintf(w, "\n// Checks return check constraints of %s table\nfunc (%s *%s) Checks() dialect.Checks {\n\treturn dialect.Checks{\n",
+			tableName, recv, structName)
+		for _, c := range t.Checks() {
+			fmt.Fprintf(w, "\t\t%s.AddCheck(%q, %q),\n", pkg, unquote(c.Name()), c.Expression())
+		}
+		fmt.Fprint(w, "\t}\n}\n")
+	}
+	return nil
+}
+
+// field writes the struct field of the column.
+func (g *generator) field(w io.Writer, dc dialect.Column, primaryKey bool) error {
+	c, ok := dc.(reader.Column)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidColumn, dc.Name())
+	}
+
+	name := goName(c.Name())
+	var notes []string
+	if nameconv.ToSnakeCase(name) != c.Name() {
+		notes = append(notes, "column: "+c.Name())
+	}
+
+	typ, ok := g.mapper.goType(parseType(c.Type()))
+	if !ok {
+		notes = append(notes, "unsupported type: "+c.Type())
+		fmt.Fprintf(w, "\t%s string `ddl:\"-\"` // %s\n", name, strings.Join(notes, ", "))
+		return nil
+	}
+	g.use(typ.path)
+
+	var tags []string
+	tags = append(tags, typ.tags...)
+	if c.Charset() != "" {
+		tags = append(tags, "charset="+c.Charset())
+	}
+	if c.Collation() != "" {
+		tags = append(tags, "collate="+c.Collation())
+	}
+	goType := typ.name
+	if c.Null() && !primaryKey {
+		tags = append(tags, "null")
+		if typ.pointer {
+			goType = "*" + goType
+		}
+	}
+	if v, ok := c.Default(); ok {
+		if tagValue(v) {
+			tags = append(tags, "default="+v)
+		} else {
+			notes = append(notes, "default: "+v)
+		}
+	}
+	if v, ok := c.OnUpdate(); ok {
+		if tagValue(v) {
+			tags = append(tags, "update="+v)
+		} else {
+			notes = append(notes, "on update: "+v)
+		}
+	}
+	if c.AutoIncrement() {
+		tags = append(tags, "auto")
+	}
+	if v, ok := c.Generated(); ok {
+		if generatedTagValue(v) {
+			tags = append(tags, "generated="+v)
+			if c.Stored() {
+				tags = append(tags, "stored")
+			}
+		} else {
+			notes = append(notes, "generated: "+v)
+		}
+	}
+	if c.HasComment() && c.Comment() != c.Name() {
+		if tagValue(c.Comment()) {
+			tags = append(tags, "comment="+c.Comment())
+		} else {
+			fmt.Fprintf(w, "\t// %s\n", strings.ReplaceAll(c.Comment(), "\n", " "))
+		}
+	}
+
+	fmt.Fprintf(w, "\t%s %s", name, goType)
+	if len(tags) > 0 {
+		fmt.Fprintf(w, " `ddl:\"%s\"`", strings.Join(tags, ","))
+	}
+	if len(notes) > 0 {
+		fmt.Fprintf(w, " // %s", strings.Join(notes, ", "))
+	}
+	fmt.Fprint(w, "\n")
+	return nil
+}
+
+// tableOptions returns the fields of dialect.TableOptions literal. AUTO_INCREMENT
+// is not generated because it is the current counter in dumped DDL.
+func tableOptions(o dialect.TableOptions) string {
+	var fields []string
+	for _, f := range []struct{ name, value string }{
+		{"Comment", o.Comment}, {"Engine", o.Engine}, {"Charset", o.Charset},
+		{"Collation", o.Collation}, {"RowFormat", o.RowFormat},
+	} {
+		if f.value != "" {
+			fields = append(fields, fmt.Sprintf("%s: %q", f.name, f.value))
+		}
+	}
+	if o.KeyBlockSize > 0 {
+		fields = append(fields, fmt.Sprintf("KeyBlockSize: %d", o.KeyBlockSize))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// foreignKey returns the constructor call of the foreign key.
+func foreignKey(pkg string, fk dialect.ForeignKey) string {
+	args := []string{
+		fmt.Sprintf("[]string{%s}", quoteAll(fk.ForeignColumns())),
+		fmt.Sprintf("[]string{%s}", quoteAll(fk.ReferenceColumns())),
+		strconv.Quote(unquote(fk.ReferenceTableName())),
+	}
+	if v, ok := fk.(dialect.NamedForeignKey); ok && v.Name() != "" {
+		args = append(args, fmt.Sprintf("%s.WithNameForeignKeyOption(%s)", pkg, strconv.Quote(v.Name())))
+	}
+	if opt := foreignKeyOption(fk.DeleteOption()); opt != "" {
+		args = append(args, fmt.Sprintf("%s.WithDeleteForeignKeyOption(%s.%s)", pkg, pkg, opt))
+	}
+	if opt := foreignKeyOption(fk.UpdateOption()); opt != "" {
+		args = append(args, fmt.Sprintf("%s.WithUpdateForeignKeyOption(%s.%s)", pkg, pkg, opt))
+	}
+	return fmt.Sprintf("%s.AddForeignKey(%s)", pkg, strings.Join(args, ", "))
+}
+
+// foreignKeyOption returns the name of ForeignKeyOptionType variable.
+func foreignKeyOption(action string) string {
+	switch strings.ToUpper(action) {
+	case "CASCADE":
+		return "ForeignKeyOptionCascade"
+	case "SET NULL":
+		return "ForeignKeyOptionSetNull"
+	case "SET DEFAULT":
+		return "ForeignKeyOptionSetDefault"
+	case "RESTRICT":
+		return "ForeignKeyOptionRestrict"
+	}
+	return ""
+}
+
+// goName converts snake case name into Go name (e.g. player_id -> PlayerID).
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		if upper := strings.ToUpper(part); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	s := b.String()
+	if s == "" || s[0] >= '0' && s[0] <= '9' {
+		s = "T" + s
+	}
+	return s
+}
+
+// tagValue reports whether s can be written as a value of ddl tag.
+func tagValue(s string) bool {
+	return s != "" && !strings.ContainsAny(s, ", =\"`\t\n")
+}
+
+// generatedTagValue reports whether the expression can be written as a value
+// of generated tag. Commas are allowed in parentheses.
+func generatedTagValue(s string) bool {
+	if s == "" || strings.ContainsAny(s, "\"`\t\n") {
+		return false
+	}
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, "`\"[]")
+}
+
+func quoteAll(ss []string) string {
+	quoted := make([]string, 0, len(ss))
+	for _, s := range ss {
+		quoted = append(quoted, strconv.Quote(unquote(s)))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,211 @@
+package reverse
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mnhkahn/ddl-maker/dialect"
+	"github.com/mnhkahn/ddl-maker/dialect/mock"
+	"github.com/mnhkahn/ddl-maker/dialect/mysql"
+	"github.com/mnhkahn/ddl-maker/dialect/sqlite"
+	"github.com/mnhkahn/ddl-maker/reader"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("[Normal] MySQL", func(t *testing.T) {
+		ddl := "CREATE TABLE `user_profile` (\n" +
+			"  `id` BIGINT unsigned NOT NULL AUTO_INCREMENT,\n" +
+			"  `user_id` INTEGER NOT NULL,\n" +
+			"  `avatar_url` VARCHAR(255) CHARACTER SET ascii COLLATE ascii_bin NULL COMMENT 'image',\n" +
+			"  `bio` TEXT NULL COMMENT 'about the user',\n" +
+			"  `active` TINYINT(1) NOT NULL DEFAULT 1,\n" +
+			"  `price` DECIMAL(10,2) NOT NULL,\n" +
+			"  `flags` BIT(8) NOT NULL,\n" +
+			"  `status` ENUM('active','banned') NOT NULL,\n" +
+			"  `updated_at` DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),\n" +
+			"  `settings` JSON NULL,\n" +
+			"  `theme` VARCHAR(191) AS (json_unquote(json_extract(settings,'$.theme'))) STORED NULL,\n" +
+			"  UNIQUE `user_id_uniq` (`user_id`),\n" +
+			"  CONSTRAINT `user_profile_ibfk_1` FOREIGN KEY (`user_id`) REFERENCES `user` (`id`) ON DELETE CASCADE,\n" +
+			"  CONSTRAINT `price_check` CHECK (`price` >= 0),\n" +
+			"  PRIMARY KEY (`id`)\n" +
+			") ENGINE=InnoDB AUTO_INCREMENT=3 COMMENT='profiles';"
+		tables, err := reader.Read(mysql.MySQL{}, ddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got bytes.Buffer
+		if err := Generate(&got, mysql.MySQL{}, "model", tables); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "package model\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"encoding/json\"\n" +
+			"\t\"time\"\n" +
+			"\n" +
+			"\t\"github.com/mnhkahn/ddl-maker/dialect\"\n" +
+			"\t\"github.com/mnhkahn/ddl-maker/dialect/mysql\"\n" +
+			")\n" +
+			"\n" +
+			"// UserProfile is the struct of user_profile table.\n" +
+			"type UserProfile struct {\n" +
+			"\tID        uint64 `ddl:\"auto\"`\n" +
+			"\tUserID    int32\n" +
+			"\tAvatarURL *string `ddl:\"size=255,charset=ascii,collate=ascii_bin,null,comment=image\"`\n" +
+			"\t// about the user\n" +
+			"\tBio       *string         `ddl:\"type=text,null\"`\n" +
+			"\tActive    bool            `ddl:\"default=1\"`\n" +
+			"\tPrice     string          `ddl:\"type=decimal,precision=10,scale=2\"`\n" +
+			"\tFlags     string          `ddl:\"-\"` // unsupported type: BIT(8)\n" +
+			"\tStatus    string          `ddl:\"enum=active|banned\"`\n" +
+			"\tUpdatedAt time.Time       `ddl:\"size=6,default=CURRENT_TIMESTAMP(6),update=CURRENT_TIMESTAMP(6)\"`\n" +
+			"\tSettings  json.RawMessage `ddl:\"null\"`\n" +
+			"\tTheme     *string         `ddl:\"null,generated=json_unquote(json_extract(settings,'$.theme')),stored\"`\n" +
+			"}\n" +
+			"\n" +
+			"// Table return table name\n" +
+			"func (u *UserProfile) Table() string {\n" +
+			"\treturn \"user_profile\"\n" +
+			"}\n" +
+			"\n" +
+			"// PrimaryKey return primary key of user_profile table\n" +
+			"func (u *UserProfile) PrimaryKey() dialect.PrimaryKey {\n" +
+			"\treturn mysql.AddPrimaryKey(\"id\")\n" +
+			"}\n" +
+			"\n" +
+			"// Indexes return indexes of user_profile table\n" +
+			"func (u *UserProfile) Indexes() dialect.Indexes {\n" +
+			"\treturn dialect.Indexes{\n" +
+			"\t\tmysql.AddUniqueIndex(\"user_id_uniq\", \"user_id\"),\n" +
+			"\t}\n" +
+			"}\n" +
+			"\n" +
+			"// ForeignKeys return foreign keys of user_profile table\n" +
+			"func (u *UserProfile) ForeignKeys() dialect.ForeignKeys {\n" +
+			"\treturn dialect.ForeignKeys{\n" +
+			"\t\tmysql.AddForeignKey([]string{\"user_id\"}, []string{\"id\"}, \"user\", mysql.WithNameForeignKeyOption(\"user_profile_ibfk_1\"), mysql.WithDeleteForeignKeyOption(mysql.ForeignKeyOptionCascade)),\n" +
+			"\t}\n" +
+			"}\n" +
+			"\n" +
+			"// TableOptions return options of user_profile table\n" +
+			"func (u *UserProfile) TableOptions() dialect.TableOptions {\n" +
+			"\treturn dialect.TableOptions{Comment: \"profiles\", Engine: \"InnoDB\"}\n" +
+			"}\n" +
+			"\n" +
+			"// Checks return check constraints of user_profile table\n" +
+			"func (u *UserProfile) Checks() dialect.Checks {\n" +
+			"\treturn dialect.Checks{\n" +
+			"\t\tmysql.AddCheck(\"price_check\", \"`price` >= 0\"),\n" +
+			"\t}\n" +
+			"}\n"
+		if diff := cmp.Diff(want, got.String()); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Normal] SQLite", func(t *testing.T) {
+		ddl := `CREATE TABLE "tag" ("id" INTEGER NOT NULL, "name" TEXT NOT NULL, "score" REAL, "data" BLOB, PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "tag_name" ON "tag" ("name");`
+		tables, err := reader.Read(sqlite.SQLite{}, ddl)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got bytes.Buffer
+		if err := Generate(&got, sqlite.SQLite{}, "model", tables); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "package model\n" +
+			"\n" +
+			"import (\n" +
+			"\t\"github.com/mnhkahn/ddl-maker/dialect\"\n" +
+			"\t\"github.com/mnhkahn/ddl-maker/dialect/sqlite\"\n" +
+			")\n" +
+			"\n" +
+			"// Tag is the struct of tag table.\n" +
+			"type Tag struct {\n" +
+			"\tID    int64\n" +
+			"\tName  string\n" +
+			"\tScore *float64 `ddl:\"null\"`\n" +
+			"\tData  []byte   `ddl:\"null\"`\n" +
+			"}\n" +
+			"\n" +
+			"// Table return table name\n" +
+			"func (t *Tag) Table() string {\n" +
+			"\treturn \"tag\"\n" +
+			"}\n" +
+			"\n" +
+			"// PrimaryKey return primary key of tag table\n" +
+			"func (t *Tag) PrimaryKey() dialect.PrimaryKey {\n" +
+			"\treturn sqlite.AddPrimaryKey(\"id\")\n" +
+			"}\n" +
+			"\n" +
+			"// Indexes return indexes of tag table\n" +
+			"func (t *Tag) Indexes() dialect.Indexes {\n" +
+			"\treturn dialect.Indexes{\n" +
+			"\t\tsqlite.AddUniqueIndex(\"tag_name\", \"tag\", \"name\"),\n" +
+			"\t}\n" +
+			"}\n"
+		if diff := cmp.Diff(want, got.String()); diff != "" {
+			t.Errorf("Compare value is mismatch (-want +got):%s\n", diff)
+		}
+	})
+
+	t.Run("[Error] dialect is not supported", func(t *testing.T) {
+		var got bytes.Buffer
+		err := Generate(&got, mock.SQLMock{}, "model", nil)
+		if !errors.Is(err, ErrNotSupported) {
+			t.Errorf("mismatch want=%v, got=%v", ErrNotSupported, err)
+		}
+	})
+
+	t.Run("[Error] column is not read from ddl", func(t *testing.T) {
+		tables := []dialect.Table{testTable{}}
+		var got bytes.Buffer
+		err := Generate(&got, mysql.MySQL{}, "model", tables)
+		if !errors.Is(err, ErrInvalidColumn) {
+			t.Errorf("mismatch want=%v, got=%v", ErrInvalidColumn, err)
+		}
+	})
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"id", "ID"},
+		{"player_id", "PlayerID"},
+		{"avatar_url", "AvatarURL"},
+		{"created_at", "CreatedAt"},
+		{"2fa", "T2fa"},
+	}
+	for _, tt := range tests {
+		if got := goName(tt.input); got != tt.want {
+			t.Errorf("goName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+type testColumn struct{}
+
+func (testColumn) Name() string           { return "id" }
+func (testColumn) Comment() string        { return "id" }
+func (testColumn) ToSQL() (string, error) { return "`id` INTEGER NOT NULL", nil }
+
+type testTable struct{}
+
+func (testTable) Name() string                     { return "`t`" }
+func (testTable) PrimaryKey() dialect.PrimaryKey   { return nil }
+func (testTable) ForeignKeys() dialect.ForeignKeys { return nil }
+func (testTable) Indexes() dialect.Indexes         { return nil }
+func (testTable) Checks() dialect.Checks           { return nil }
+func (testTable) Options() dialect.TableOptions    { return dialect.TableOptions{} }
+func (testTable) Columns() []dialect.Column        { return []dialect.Column{testColumn{}} }
+func (testTable) Dialect() dialect.Dialect         { return mysql.MySQL{} }
@@ -11,6 +11,8 @@ type table struct {
 	foreignKeys dialect.ForeignKeys
 	columns     []dialect.Column
 	indexes     dialect.Indexes
+	checks      dialect.Checks
+	options     dialect.TableOptions
 	dialect     dialect.Dialect
 }
 
@@ -45,6 +47,14 @@ func (t table) Indexes() dialect.Indexes {
 	return t.indexes
 }
 
+func (t table) Checks() dialect.Checks {
+	return t.checks
+}
+
+func (t table) Options() dialect.TableOptions {
+	return t.options
+}
+
 func (t table) Dialect() dialect.Dialect {
 	return t.dialect
 }
@@ -0,0 +1,7 @@
+package doc
+
+// Note is declared by the file selected by build constraints.
+type Note struct {
+	// Body is the body of the note
+	Body string
+}
@@ -0,0 +1,9 @@
+//go:build ignore
+
+package doc
+
+// Note is declared by the file excluded by build constraints.
+type Note struct {
+	// Body is excluded
+	Body string
+}
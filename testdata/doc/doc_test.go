@@ -0,0 +1,12 @@
+package doc
+
+// Note is declared by the test file.
+type Note struct {
+	Body string
+}
+
+// Fixture is declared only by the test file.
+type Fixture struct {
+	// Name is the name of the fixture
+	Name string
+}
@@ -3,21 +3,10 @@ SET foreign_key_checks=0;
 DROP TABLE IF EXISTS `test_one`;
 
 CREATE TABLE `test_one` (
-    `id` BIGINT unsigned NOT NULL,
-    `name` VARCHAR(191) NOT NULL,
-    `created_at` DATETIME NOT NULL,
-    `updated_at` DATETIME NOT NULL,
-    PRIMARY KEY (`id`)
-) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4;
-
-
-DROP TABLE IF EXISTS `test_one`;
-
-CREATE TABLE `test_one` (
-    `id` BIGINT unsigned NOT NULL,
-    `name` VARCHAR(191) NOT NULL,
-    `created_at` DATETIME NOT NULL,
-    `updated_at` DATETIME NOT NULL,
+    `id` BIGINT unsigned NOT NULL COMMENT 'id',
+    `name` VARCHAR(191) NOT NULL COMMENT 'name',
+    `created_at` DATETIME NOT NULL COMMENT 'created_at',
+    `updated_at` DATETIME NOT NULL COMMENT 'updated_at',
     PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4;
 
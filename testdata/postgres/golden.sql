@@ -0,0 +1,37 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "account" CASCADE;
+
+CREATE TABLE "account" (
+    "id" BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    "name" VARCHAR(100) NOT NULL,
+    "active" BOOLEAN NOT NULL DEFAULT true,
+    "created_at" TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+COMMENT ON COLUMN "account"."id" IS 'id';
+COMMENT ON COLUMN "account"."name" IS 'account''s_name';
+COMMENT ON COLUMN "account"."active" IS 'active';
+COMMENT ON COLUMN "account"."created_at" IS 'created_at';
+
+DROP TABLE IF EXISTS "session" CASCADE;
+
+CREATE TABLE "session" (
+    "id" BIGINT NOT NULL GENERATED BY DEFAULT AS IDENTITY,
+    "account_id" BIGINT NOT NULL,
+    "token" TEXT NOT NULL,
+    "payload" JSONB NOT NULL,
+    "expired_at" TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY ("account_id") REFERENCES "account" ("id") ON DELETE CASCADE,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX "expired_at_idx" ON "session" ("expired_at");
+CREATE UNIQUE INDEX "token_uniq_idx" ON "session" ("token");
+COMMENT ON COLUMN "session"."id" IS 'id';
+COMMENT ON COLUMN "session"."account_id" IS 'account_id';
+COMMENT ON COLUMN "session"."token" IS 'token';
+COMMENT ON COLUMN "session"."payload" IS 'payload';
+COMMENT ON COLUMN "session"."expired_at" IS 'expired_at';
+COMMIT;
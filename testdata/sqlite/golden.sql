@@ -19,7 +19,7 @@ CREATE TABLE `entry` (
     `player_id` INTEGER NOT NULL,
     `title` TEXT NOT NULL,
     `public` INTEGER NOT NULL DEFAULT 0,
-    `content` TEXT NOT NULL,
+    `content` TEXT NULL,
     `created_at` INTEGER NOT NULL,
     `updated_at` INTEGER NOT NULL,
     FOREIGN KEY (`player_id`) REFERENCES `player` (`id`) ON DELETE CASCADE,
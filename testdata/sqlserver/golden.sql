@@ -0,0 +1,21 @@
+SET ANSI_NULLS ON;
+SET QUOTED_IDENTIFIER ON;
+GO
+
+IF OBJECT_ID(N'[report]', N'U') IS NOT NULL DROP TABLE [report];
+GO
+
+CREATE TABLE [report] (
+    [id] BIGINT NOT NULL IDENTITY(1,1),
+    [account_id] BIGINT NOT NULL,
+    [title] NVARCHAR(100) NOT NULL,
+    [body] NVARCHAR(MAX) NULL,
+    [published] BIT NOT NULL DEFAULT 0,
+    [created_at] DATETIME2 NOT NULL,
+    FOREIGN KEY ([account_id]) REFERENCES [account] ([id]) ON DELETE CASCADE,
+    PRIMARY KEY ([id])
+);
+GO
+
+CREATE INDEX [created_at_idx] ON [report] ([created_at]);
+GO